@@ -0,0 +1,283 @@
+package cloth
+
+import (
+	"github.com/rudransh61/Physix-go/dynamics/xpbd"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// LinkKind tells what a link of the cloth holds.
+type LinkKind int
+
+const (
+	Structural LinkKind = iota // Between neighbours along a row or column
+	Shear                      // Across the diagonals of a cell
+)
+
+// Link is a distance constraint between two particles of the cloth. A link
+// stretched past the cloth's TearLimit tears and stops holding.
+type Link struct {
+	*xpbd.Distance
+	Kind LinkKind
+	Torn bool
+}
+
+// Cloth is a grid of particles held by structural, shear and bending
+// constraints and moved by an XPBD solver. It can be pinned, blown by wind
+// and torn.
+type Cloth struct {
+	Columns, Rows int
+	Particles     []*rigidbody.RigidBody // Row by row, from the top-left corner
+	Links         []*Link
+	Solver        *xpbd.Solver  // Set Solver.SelfCollide to keep the cloth from passing through itself
+	Wind          vector.Vector // Velocity of the air
+	Drag          float64       // Aerodynamic drag per unit of area and relative air speed
+	TearLimit     float64       // Stretch, as a share of the rest length, that tears a link; 0 never tears
+
+	bends     []bend
+	triangles []triangle
+}
+
+// bend is a bending constraint along a row or column and the two links it
+// spans, so it can be dropped when either tears.
+type bend struct {
+	constraint *xpbd.Bending
+	links      [2]*Link
+}
+
+// triangle is half a cell of the grid, used for wind and drawing.
+type triangle struct {
+	particles [3]*rigidbody.RigidBody
+	links     [3]*Link
+}
+
+// New creates a cloth of columns by rows particles spaced apart, with its
+// top-left corner at origin, hanging under gravity. The mass is shared evenly
+// by the particles. Structural links are rigid; see SetCompliance.
+func New(origin vector.Vector, columns, rows int, spacing, mass float64, gravity vector.Vector) *Cloth {
+	c := &Cloth{
+		Columns: columns,
+		Rows:    rows,
+		Solver:  xpbd.NewSolver(gravity),
+		Drag:    0.01,
+	}
+	for row := 0; row < rows; row++ {
+		for column := 0; column < columns; column++ {
+			position := origin.Add(vector.Vector{X: float64(column) * spacing, Y: float64(row) * spacing})
+			c.Particles = append(c.Particles, xpbd.NewParticle(position, mass/float64(columns*rows), spacing*0.4))
+		}
+	}
+	c.Solver.Add(c.Particles...)
+
+	right := make(map[int]*Link) // Structural links by their left or top particle
+	down := make(map[int]*Link)
+	for row := 0; row < rows; row++ {
+		for column := 0; column < columns; column++ {
+			i := row*columns + column
+			if column+1 < columns {
+				right[i] = c.link(i, i+1, Structural)
+			}
+			if row+1 < rows {
+				down[i] = c.link(i, i+columns, Structural)
+			}
+		}
+	}
+	for row := 0; row+1 < rows; row++ {
+		for column := 0; column+1 < columns; column++ {
+			i := row*columns + column
+			diagonal := c.link(i+1, i+columns, Shear)
+			c.link(i, i+columns+1, Shear)
+			c.triangles = append(c.triangles,
+				triangle{
+					particles: [3]*rigidbody.RigidBody{c.Particles[i], c.Particles[i+1], c.Particles[i+columns]},
+					links:     [3]*Link{right[i], diagonal, down[i]},
+				},
+				triangle{
+					particles: [3]*rigidbody.RigidBody{c.Particles[i+1], c.Particles[i+columns+1], c.Particles[i+columns]},
+					links:     [3]*Link{down[i+1], right[i+columns], diagonal},
+				})
+		}
+	}
+	for row := 0; row < rows; row++ {
+		for column := 0; column < columns; column++ {
+			i := row*columns + column
+			if column+2 < columns {
+				c.bend(i, i+1, i+2, right[i], right[i+1])
+			}
+			if row+2 < rows {
+				c.bend(i, i+columns, i+2*columns, down[i], down[i+columns])
+			}
+		}
+	}
+	c.SetCompliance(0, 0.0001, 0.01)
+	return c
+}
+
+func (c *Cloth) link(a, b int, kind LinkKind) *Link {
+	l := &Link{Distance: xpbd.NewDistance(c.Particles[a], c.Particles[b], 0), Kind: kind}
+	c.Links = append(c.Links, l)
+	c.Solver.AddConstraint(l.Distance)
+	return l
+}
+
+func (c *Cloth) bend(a, b, d int, first, second *Link) {
+	constraint := xpbd.NewBending(c.Particles[a], c.Particles[b], c.Particles[d], 0)
+	c.bends = append(c.bends, bend{constraint: constraint, links: [2]*Link{first, second}})
+	c.Solver.AddConstraint(constraint)
+}
+
+// SetCompliance sets how much the structural and shear links stretch and how
+// easily the cloth folds. 0 is rigid and larger values are softer.
+func (c *Cloth) SetCompliance(structural, shear, bending float64) {
+	for _, l := range c.Links {
+		if l.Kind == Structural {
+			l.Compliance = structural
+		} else {
+			l.Compliance = shear
+		}
+	}
+	for _, b := range c.bends {
+		b.constraint.Compliance = bending
+	}
+}
+
+// Particle returns the particle at a column and row of the grid.
+func (c *Cloth) Particle(column, row int) *rigidbody.RigidBody {
+	return c.Particles[row*c.Columns+column]
+}
+
+// Pin holds the particle at a column and row where it is.
+func (c *Cloth) Pin(column, row int) {
+	p := c.Particle(column, row)
+	p.SetType(rigidbody.Static)
+	p.Velocity = vector.Vector{}
+}
+
+// Unpin lets a pinned particle move again.
+func (c *Cloth) Unpin(column, row int) {
+	c.Particle(column, row).SetType(rigidbody.Dynamic)
+}
+
+// AddCircle adds a static circle the cloth drapes over and returns its body.
+// Give the body a mass and make it dynamic to have the cloth push it about;
+// the cloth's solver then moves it.
+func (c *Cloth) AddCircle(center vector.Vector, radius float64) *rigidbody.RigidBody {
+	body := &rigidbody.RigidBody{Position: center, Shape: "Circle", Radius: radius, Type: rigidbody.Static}
+	c.AddObstacle(body)
+	return body
+}
+
+// AddRectangle adds a static rectangle centred on center, turned by angle,
+// that the cloth drapes over, and returns its body.
+func (c *Cloth) AddRectangle(center vector.Vector, width, height, angle float64) *rigidbody.RigidBody {
+	body := &rigidbody.RigidBody{Position: center, Shape: "Rectangle", Width: width, Height: height, Angle: angle, Type: rigidbody.Static}
+	c.AddObstacle(body)
+	return body
+}
+
+// AddObstacle adds any body for the cloth to collide with.
+func (c *Cloth) AddObstacle(body *rigidbody.RigidBody) {
+	c.Solver.Colliders = append(c.Solver.Colliders, body)
+}
+
+// RemoveObstacle stops the cloth colliding with a body.
+func (c *Cloth) RemoveObstacle(body *rigidbody.RigidBody) {
+	kept := c.Solver.Colliders[:0]
+	for _, b := range c.Solver.Colliders {
+		if b != body {
+			kept = append(kept, b)
+		}
+	}
+	c.Solver.Colliders = kept
+}
+
+// Step blows the wind on the cloth, moves it by dt and tears the links that
+// stretched too far.
+func (c *Cloth) Step(dt float64) {
+	c.applyWind(dt)
+	c.Solver.Step(dt)
+	c.tear()
+}
+
+// Triangles returns the corners of every triangle of the cloth that has not
+// been torn, for drawing.
+func (c *Cloth) Triangles() [][3]*rigidbody.RigidBody {
+	var out [][3]*rigidbody.RigidBody
+	for _, t := range c.triangles {
+		if !t.torn() {
+			out = append(out, t.particles)
+		}
+	}
+	return out
+}
+
+func (t triangle) torn() bool {
+	for _, l := range t.links {
+		if l == nil || l.Torn {
+			return true
+		}
+	}
+	return false
+}
+
+// applyWind pushes every triangle with a drag force proportional to its area
+// and to the velocity of the air relative to it, shared by its corners.
+func (c *Cloth) applyWind(dt float64) {
+	if c.Drag == 0 {
+		return
+	}
+	for _, t := range c.triangles {
+		if t.torn() {
+			continue
+		}
+		a, b, d := t.particles[0], t.particles[1], t.particles[2]
+		area := b.Position.Sub(a.Position).Cross(d.Position.Sub(a.Position)) / 2
+		if area < 0 {
+			area = -area
+		}
+		velocity := a.Velocity.Add(b.Velocity).Add(d.Velocity).Scale(1.0 / 3)
+		force := c.Wind.Sub(velocity).Scale(c.Drag * area / 3)
+		for _, p := range t.particles {
+			p.Velocity = p.Velocity.Add(force.Scale(p.InverseMass() * dt))
+		}
+	}
+}
+
+// tear breaks the links stretched past TearLimit and drops their constraints,
+// along with the bending constraints that span them, from the solver.
+func (c *Cloth) tear() {
+	if c.TearLimit <= 0 {
+		return
+	}
+	torn := false
+	for _, l := range c.Links {
+		if l.Torn || l.Rest == 0 {
+			continue
+		}
+		if vector.Distance(l.A.Position, l.B.Position)/l.Rest-1 > c.TearLimit {
+			l.Torn = true
+			torn = true
+		}
+	}
+	if !torn {
+		return
+	}
+	dropped := make(map[xpbd.Constraint]bool)
+	for _, l := range c.Links {
+		if l.Torn {
+			dropped[l.Distance] = true
+		}
+	}
+	for _, b := range c.bends {
+		if b.links[0].Torn || b.links[1].Torn {
+			dropped[b.constraint] = true
+		}
+	}
+	kept := c.Solver.Constraints[:0]
+	for _, constraint := range c.Solver.Constraints {
+		if !dropped[constraint] {
+			kept = append(kept, constraint)
+		}
+	}
+	c.Solver.Constraints = kept
+}
@@ -0,0 +1,65 @@
+package collision
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// AABB is an axis-aligned bounding box.
+type AABB struct {
+	Min, Max vector.Vector
+}
+
+// BoundsOf returns the axis-aligned bounding box of a circle, rectangle,
+// capsule or segment body, or of all the fixtures of a body.
+func BoundsOf(rb *rigidbody.RigidBody) AABB {
+	if len(rb.Fixtures) > 0 {
+		shapes := shapesOf(rb)
+		if len(shapes) == 0 {
+			return AABB{Min: rb.Position, Max: rb.Position}
+		}
+		box := shapes[0].bounds()
+		for _, s := range shapes[1:] {
+			box = box.Union(s.bounds())
+		}
+		return box
+	}
+	switch rb.Shape {
+	case "Circle":
+		r := vector.Vector{X: rb.Radius, Y: rb.Radius}
+		return AABB{Min: rb.Position.Sub(r), Max: rb.Position.Add(r)}
+	case "Capsule", "Segment":
+		a, b := rb.SegmentEnds()
+		return boundsOfPoints([]vector.Vector{a, b}).Expand(rb.Radius)
+	}
+	return boundsOfPoints(rb.Corners())
+}
+
+func boundsOfPoints(points []vector.Vector) AABB {
+	box := AABB{Min: points[0], Max: points[0]}
+	for _, p := range points[1:] {
+		box = box.Union(AABB{Min: p, Max: p})
+	}
+	return box
+}
+
+// Overlaps reports whether two boxes intersect or touch.
+func (a AABB) Overlaps(b AABB) bool {
+	return a.Max.X >= b.Min.X && a.Min.X <= b.Max.X && a.Max.Y >= b.Min.Y && a.Min.Y <= b.Max.Y
+}
+
+// Expand grows the box by margin on every side.
+func (a AABB) Expand(margin float64) AABB {
+	m := vector.Vector{X: margin, Y: margin}
+	return AABB{Min: a.Min.Sub(m), Max: a.Max.Add(m)}
+}
+
+// Union returns the smallest box containing both boxes.
+func (a AABB) Union(b AABB) AABB {
+	return AABB{
+		Min: vector.Vector{X: math.Min(a.Min.X, b.Min.X), Y: math.Min(a.Min.Y, b.Min.Y)},
+		Max: vector.Vector{X: math.Max(a.Max.X, b.Max.X), Y: math.Max(a.Max.Y, b.Max.Y)},
+	}
+}
@@ -0,0 +1,207 @@
+package collision
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/pkg/material"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Chain is static level geometry made of connected edges, such as the ground
+// of a platformer. Edges are one-sided: bodies collide with the side the edge
+// normal points to and pass through from behind. Walking a chain left to
+// right on screen, the normals point up.
+//
+// The neighbours of each edge act as ghost vertices, so a body sliding across
+// the joint between two edges does not catch on the corner between them.
+type Chain struct {
+	Vertices []vector.Vector
+	Loop     bool // The last vertex joins back to the first
+	body     rigidbody.RigidBody
+}
+
+// NewChain creates an open chain through the given vertices.
+func NewChain(vertices []vector.Vector) *Chain {
+	return &Chain{
+		Vertices: vertices,
+		body:     rigidbody.RigidBody{Shape: "Chain", Type: rigidbody.Static},
+	}
+}
+
+// NewLoop creates a closed chain around the given vertices, in either winding.
+// Its normals point out of the loop.
+func NewLoop(vertices []vector.Vector) *Chain {
+	c := NewChain(newConvex(vertices, 0).verts)
+	c.Loop = true
+	return c
+}
+
+// Body returns the static body that stands for the chain in its manifolds.
+func (c *Chain) Body() *rigidbody.RigidBody {
+	return &c.body
+}
+
+// SetMaterial sets the material used for friction and restitution against the chain.
+func (c *Chain) SetMaterial(m *material.Material) {
+	c.body.Material = m
+}
+
+// EdgeCount returns the number of edges of the chain.
+func (c *Chain) EdgeCount() int {
+	if c.Loop {
+		return len(c.Vertices)
+	}
+	return len(c.Vertices) - 1
+}
+
+// Edge returns edge i as v1-v2 along with its ghost vertices v0 and v3.
+// hasV0 and hasV3 are false at the open ends of a chain.
+func (c *Chain) Edge(i int) (v0, v1, v2, v3 vector.Vector, hasV0, hasV3 bool) {
+	n := len(c.Vertices)
+	v1 = c.Vertices[i]
+	v2 = c.Vertices[(i+1)%n]
+	if c.Loop || i > 0 {
+		v0, hasV0 = c.Vertices[(i-1+n)%n], true
+	}
+	if c.Loop || i+2 < n {
+		v3, hasV3 = c.Vertices[(i+2)%n], true
+	}
+	return
+}
+
+// Bounds returns the bounding box of the whole chain.
+func (c *Chain) Bounds() AABB {
+	return boundsOfPoints(c.Vertices)
+}
+
+// Collide returns a manifold for every edge the body touches. Body1 of each
+// manifold is the chain's Body and the normal points from the chain to body.
+// Sensor fixtures of the body are left out.
+func (c *Chain) Collide(body *rigidbody.RigidBody) []*Manifold {
+	if len(c.Vertices) < 2 {
+		return nil
+	}
+	var manifolds []*Manifold
+	for _, s := range solidShapes(body) {
+		bounds := s.bounds()
+		if !c.Bounds().Overlaps(bounds) {
+			continue
+		}
+		for i := 0; i < c.EdgeCount(); i++ {
+			v0, v1, v2, v3, hasV0, hasV3 := c.Edge(i)
+			if !boundsOfPoints([]vector.Vector{v1, v2}).Overlaps(bounds) {
+				continue
+			}
+			normal, depth, contacts, ok := collideEdge(v0, v1, v2, v3, hasV0, hasV3, s.convex.center(), s.convex)
+			if !ok {
+				continue
+			}
+			manifolds = append(manifolds, &Manifold{Body1: &c.body, Body2: body, Fixture2: s.fixture, Normal: normal, Depth: depth, Contacts: contacts})
+		}
+	}
+	return manifolds
+}
+
+// Resolve pushes the body out of the chain and changes its velocity, like the
+// Resolve function does for two bodies. It reports whether the body touched the chain.
+func (c *Chain) Resolve(body *rigidbody.RigidBody) bool {
+	if resting(body) {
+		return false
+	}
+	manifolds := c.Collide(body)
+	Resolver{}.ResolveManifolds(manifolds)
+	return len(manifolds) > 0
+}
+
+// solidShapes returns the shapes of a body, leaving out sensor fixtures.
+func solidShapes(rb *rigidbody.RigidBody) []bodyShape {
+	shapes := shapesOf(rb)
+	out := shapes[:0]
+	for _, s := range shapes {
+		if s.fixture == nil || !s.fixture.IsSensor {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// collideEdge collides shape b, centred at center, with the one-sided edge
+// v1-v2. A contact normal that leans towards a joint is checked against the
+// neighbouring edge: where the neighbour is the closer feature it handles the
+// contact, a convex corner keeps its rounded normal, and otherwise the edge
+// normal is used so bodies do not catch on the joint.
+func collideEdge(v0, v1, v2, v3 vector.Vector, hasV0, hasV3 bool, center vector.Vector, b convex) (vector.Vector, float64, []vector.Vector, bool) {
+	edge := convex{verts: []vector.Vector{v1, v2}}
+	n1 := edge.edgeNormal(0)
+	if n1.InnerProduct(center.Sub(v1)) < 0 {
+		return vector.Vector{}, 0, nil, false
+	}
+	normal, depth, contacts, ok := collideConvex(edge, b)
+	if !ok {
+		return vector.Vector{}, 0, nil, false
+	}
+	if normal.InnerProduct(n1) > 1-1e-6 {
+		return normal, depth, contacts, true
+	}
+
+	// The normal leans towards one end of the edge; check that joint
+	tangent := v2.Sub(v1)
+	var corner, along, ghostNormal vector.Vector
+	var convexCorner bool
+	if normal.InnerProduct(tangent) < 0 {
+		if !hasV0 {
+			return normal, depth, contacts, true
+		}
+		corner, along = v1, v1.Sub(v0)
+		ghostNormal = convex{verts: []vector.Vector{v0, v1}}.edgeNormal(0)
+		convexCorner = along.Cross(tangent) > 0
+	} else {
+		if !hasV3 {
+			return normal, depth, contacts, true
+		}
+		corner, along = v2, v2.Sub(v3)
+		ghostNormal = convex{verts: []vector.Vector{v2, v3}}.edgeNormal(0)
+		convexCorner = tangent.Cross(v3.Sub(v2)) > 0
+	}
+	if along.InnerProduct(corner.Sub(closestTo(b, corner))) > 0 {
+		return vector.Vector{}, 0, nil, false // The body is beside the neighbour
+	}
+	if convexCorner && between(normal, ghostNormal, n1) {
+		return normal, depth, contacts, true
+	}
+
+	// Push out along the edge normal
+	lowest := math.Inf(1)
+	for _, v := range b.verts {
+		lowest = math.Min(lowest, n1.InnerProduct(v.Sub(v1)))
+	}
+	depth = b.radius - lowest
+	if depth <= 0 {
+		return vector.Vector{}, 0, nil, false
+	}
+	if len(b.verts) == 1 {
+		contacts = []vector.Vector{b.verts[0].Sub(n1.Scale(b.radius))}
+	} else if contacts = clipContacts(edge, b, n1, false); len(contacts) == 0 {
+		contacts = []vector.Vector{deepestPoint(edge, b, n1)}
+	}
+	return n1, depth, contacts, true
+}
+
+// closestTo returns the point of the core of c closest to p.
+func closestTo(c convex, p vector.Vector) vector.Vector {
+	best, bestDist := c.verts[0], math.Inf(1)
+	for _, e := range edges(c) {
+		q := closestOnSegment(p, e[0], e[1])
+		if d := vector.Distance(p, q); d < bestDist {
+			best, bestDist = q, d
+		}
+	}
+	return best
+}
+
+// between reports whether the unit vector n lies in the smaller angle from a to b.
+func between(n, a, b vector.Vector) bool {
+	turn := a.Cross(b)
+	return a.Cross(n)*turn >= 0 && n.Cross(b)*turn >= 0
+}
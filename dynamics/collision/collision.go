@@ -9,12 +9,16 @@ import (
 )
 
 // CheckCollision checks if two rectangles (RigidBody instances) are colliding.
+// Rectangles are centred on Position and may be rotated by Angle.
 func RectangleCollided(rect1 *rigidbody.RigidBody, rect2 *rigidbody.RigidBody) bool {
 	if rect1.Shape == rect2.Shape && rect1.Shape == "Rectangle" {
-		left1, top1, right1, bottom1 := rect1.Position.X, rect1.Position.Y, rect1.Position.X+rect1.Width, rect1.Position.Y+rect1.Height
-		left2, top2, right2, bottom2 := rect2.Position.X, rect2.Position.Y, rect2.Position.X+rect2.Width, rect2.Position.Y+rect2.Height
-
-		return right1 > left2 && left1 < right2 && bottom1 > top2 && top1 < bottom2
+		if rect1.Angle == 0 && rect2.Angle == 0 {
+			dx := math.Abs(rect1.Position.X - rect2.Position.X)
+			dy := math.Abs(rect1.Position.Y - rect2.Position.Y)
+			return dx < (rect1.Width+rect2.Width)/2 && dy < (rect1.Height+rect2.Height)/2
+		}
+		_, ok := Collide(rect1, rect2)
+		return ok
 	}
 	return false
 }
@@ -30,154 +34,145 @@ func CircleCollided(circle1 *rigidbody.RigidBody, circle2 *rigidbody.RigidBody)
 // Circle-Rectangle collision detection
 func CircleRectangleCollided(circle *rigidbody.RigidBody, rect *rigidbody.RigidBody) bool {
 	if circle.Shape == "Circle" && rect.Shape == "Rectangle" {
-		closestX := math.Max(rect.Position.X, math.Min(circle.Position.X, rect.Position.X+rect.Width))
-		closestY := math.Max(rect.Position.Y, math.Min(circle.Position.Y, rect.Position.Y+rect.Height))
-		distance := vector.Distance(vector.Vector{X: closestX, Y: closestY}, circle.Position)
-		return distance < circle.Radius
+		// Work in the rectangle's own frame so rotated rectangles are handled too
+		local := rect.LocalPoint(circle.Position)
+		closest := vector.Vector{
+			X: math.Max(-rect.Width/2, math.Min(local.X, rect.Width/2)),
+			Y: math.Max(-rect.Height/2, math.Min(local.Y, rect.Height/2)),
+		}
+		return vector.Distance(closest, local) < circle.Radius
 	}
 	return false
 }
 
+// atRest reports whether neither body can move this step, so a pair of
+// sleeping, static or halted kinematic bodies needs no resolution.
+func atRest(body1, body2 *rigidbody.RigidBody) bool {
+	return resting(body1) && resting(body2)
+}
+
+func resting(rb *rigidbody.RigidBody) bool {
+	if rb.IsKinematic() {
+		return rb.Velocity == vector.Vector{} && rb.AngularVelocity == 0
+	}
+	return rb.IsSleeping || !rb.IsDynamic()
+}
+
+// massShares splits a positional correction between two bodies by their
+// inverse masses, so static and kinematic bodies never move and a heavy body
+// moves less than a light one.
+func massShares(body1, body2 *rigidbody.RigidBody) (float64, float64) {
+	im1, im2 := body1.InverseMass(), body2.InverseMass()
+	total := im1 + im2
+	if total == 0 {
+		return 0, 0
+	}
+	return im1 / total, im2 / total
+}
+
+// wakePair wakes both bodies of a new contact.
+func wakePair(body1, body2 *rigidbody.RigidBody) {
+	if body1.IsSleeping {
+		body1.WakeUp()
+	}
+	if body2.IsSleeping {
+		body2.WakeUp()
+	}
+}
+
 // Prevent Rectangle-Rectangle Overlap
 func PreventRectangleOverlap(rect1, rect2 *rigidbody.RigidBody) {
+	if atRest(rect1, rect2) {
+		return
+	}
 	if RectangleCollided(rect1, rect2) {
-		mtvX := math.Min(rect1.Position.X+rect1.Width-rect2.Position.X, rect2.Position.X+rect2.Width-rect1.Position.X)
-		mtvY := math.Min(rect1.Position.Y+rect1.Height-rect2.Position.Y, rect2.Position.Y+rect2.Height-rect1.Position.Y)
-
-		if rect1.IsMovable && rect2.IsMovable {
-			if mtvX < mtvY {
-				if rect1.Position.X < rect2.Position.X {
-					rect1.Position.X -= mtvX / 2
-					rect2.Position.X += mtvX / 2
-				} else {
-					rect1.Position.X += mtvX / 2
-					rect2.Position.X -= mtvX / 2
-				}
-			} else {
-				if rect1.Position.Y < rect2.Position.Y {
-					rect1.Position.Y -= mtvY / 2
-					rect2.Position.Y += mtvY / 2
-				} else {
-					rect1.Position.Y += mtvY / 2
-					rect2.Position.Y -= mtvY / 2
-				}
-			}
+		wakePair(rect1, rect2)
+		if m, ok := Collide(rect1, rect2); ok {
+			// Push the rectangles apart along the axis of least overlap, each by
+			// its share of the total inverse mass
+			Separate(m, 1, 0)
 		}
-
-		if rect2.IsMovable && !rect1.IsMovable {
-			if mtvX < mtvY {
-				if rect2.Position.X < rect1.Position.X {
-					rect2.Position.X -= mtvX
-				} else {
-					rect2.Position.X += mtvX
-				}
-			} else {
-				if rect2.Position.Y < rect1.Position.Y {
-					rect2.Position.Y -= mtvY
-				} else {
-					rect2.Position.Y += mtvY
-				}
-			}
-		}
-		if rect1.IsMovable && !rect2.IsMovable {
-			if mtvX < mtvY {
-				if rect1.Position.X < rect2.Position.X {
-					rect1.Position.X -= mtvX
-				} else {
-					rect1.Position.X += mtvX
-				}
-			} else {
-				if rect1.Position.Y < rect2.Position.Y {
-					rect1.Position.Y -= mtvY
-				} else {
-					rect1.Position.Y += mtvY
-				}
-			}
-		}
-
 	}
 }
 
 // Prevent Circle-Circle Overlap
 func PreventCircleOverlap(circle1, circle2 *rigidbody.RigidBody) {
+	if atRest(circle1, circle2) {
+		return
+	}
 	if CircleCollided(circle1, circle2) {
+		wakePair(circle1, circle2)
 		delta := circle2.Position.Sub(circle1.Position)
 		distance := delta.Magnitude()
-		overlap := (circle1.Radius + circle2.Radius - distance) / 2
+		overlap := circle1.Radius + circle2.Radius - distance
 		if distance > 0 {
+			share1, share2 := massShares(circle1, circle2)
 			correction := delta.Normalize().Scale(overlap)
-			circle1.Position = circle1.Position.Sub(correction)
-			circle2.Position = circle2.Position.Add(correction)
+			circle1.Position = circle1.Position.Sub(correction.Scale(share1))
+			circle2.Position = circle2.Position.Add(correction.Scale(share2))
 		}
 	}
 }
 
 // Prevent Circle-Rectangle Overlap
 func PreventCircleRectangleOverlap(circle, rect *rigidbody.RigidBody) {
+	if atRest(circle, rect) {
+		return
+	}
 	if CircleRectangleCollided(circle, rect) {
-		closestX := math.Max(rect.Position.X, math.Min(circle.Position.X, rect.Position.X+rect.Width))
-		closestY := math.Max(rect.Position.Y, math.Min(circle.Position.Y, rect.Position.Y+rect.Height))
-		closestPoint := vector.Vector{X: closestX, Y: closestY}
-		delta := circle.Position.Sub(closestPoint)
-		distance := delta.Magnitude()
-		overlap := circle.Radius - distance
-		if distance > 0 {
-			correction := delta.Normalize().Scale(overlap)
-			circle.Position = circle.Position.Add(correction)
+		wakePair(circle, rect)
+		if m, ok := Collide(circle, rect); ok {
+			Separate(m, 1, 0)
 		}
 	}
 }
 
+// BounceOnCollision bounces two touching bodies off each other along the line
+// joining their centres. The restitution comes from the bodies' materials and
+// their Restitution fields, like Resolve, which also handles friction and
+// rotation.
+//
+// e is deprecated: it is only used when neither body has a Material or a
+// Restitution, so older code that passed the restitution in still bounces.
 func BounceOnCollision(body1, body2 *rigidbody.RigidBody, e float64) {
 	// fmt.Println("Entering BounceOnCollision function")
 	// defer fmt.Println("Exiting BounceOnCollision function")
+	if atRest(body1, body2) {
+		return
+	}
+	wakePair(body1, body2)
+	if hasRestitution(body1) || hasRestitution(body2) {
+		e = MixMaterials(body1, body2).Restitution
+	}
 
-	if body1.IsMovable && body2.IsMovable {
-		// Calculate the center of mass velocities
-		v1 := body1.Velocity
-		m1 := body1.Mass
-		v2 := body2.Velocity
-		m2 := body2.Mass
-
-		//body1.Velocity = v1.Scale((m1 - e*m2) / (m1 + m2)).Add(v2.Scale((1 + e) * m2 / (m1 + m2)))
-		//body2.Velocity = v2.Scale((m2 - e*m1) / (m1 + m2)).Add(v1.Scale((1 + e) * m1 / (m1 + m2)))
-		// Velocity after
-		center1 := vector.NewVector(0, 0)
-		if body1.Shape == "Circle" {
-			center1.X = body1.Position.X
-			center1.Y = body1.Position.Y
-		} else {
-			center1.X = body1.Position.X + body1.Width/2
-			center1.Y = body1.Position.Y + body1.Height/2
-		}
+	im1, im2 := body1.InverseMass(), body2.InverseMass()
+	if im1 > 0 && im2 > 0 {
+		// Exchange momentum along the line joining the centres; the
+		// perpendicular parts of the velocities are kept as they are
+		normal := body2.Position.Sub(body1.Position).Normalize()
+		relativeVelocity := body2.Velocity.Sub(body1.Velocity).InnerProduct(normal)
+		j := -(1 + e) * relativeVelocity / (im1 + im2)
+		body1.Velocity = body1.Velocity.Sub(normal.Scale(j * im1))
+		body2.Velocity = body2.Velocity.Add(normal.Scale(j * im2))
+	} else if im1 > 0 {
+		// Bounce only body1, relative to body2 so a kinematic body carries it along
+		body1.Velocity = bounceOff(body1.Velocity, body2.Velocity, e)
+	} else if im2 > 0 {
+		// Bounce only body2
+		body2.Velocity = bounceOff(body2.Velocity, body1.Velocity, e)
+	}
+	// No bounce if neither body can be moved
+}
 
-		center2 := vector.NewVector(0, 0)
-		if body2.Shape == "Circle" {
-			center2.X = body2.Position.X
-			center2.Y = body2.Position.Y
-		} else {
-			center2.X = body2.Position.X + body2.Width/2
-			center2.Y = body2.Position.Y + body2.Height/2
-		}
-		r12 := center2.Sub(center1)
-		r21 := center1.Sub(center2)
-		v1alongr12 := r12.Normalize().Scale(v1.InnerProduct(r12.Normalize()))
-		v2alongr21 := r21.Normalize().Scale(v2.InnerProduct(r21.Normalize()))
-		v1perp := v1.Sub(v1alongr12)
-		v2perp := v2.Sub(v2alongr21)
-		newVelocity1normal := v1alongr12.Scale((m1 - e*m2) / (m1 + m2)).Add(v2alongr21.Scale((1 + e) * m2 / (m1 + m2)))
-		newVelocity2normal := v2alongr21.Scale((m2 - e*m1) / (m1 + m2)).Add(v1alongr12.Scale((1 + e) * m1 / (m1 + m2)))
-		newVecloity1 := newVelocity1normal.Add(v1perp)
-		newVecloity2 := newVelocity2normal.Add(v2perp)
-
-		body1.Velocity = newVecloity1
-		body2.Velocity = newVecloity2
-	} else if body1.IsMovable && !body2.IsMovable {
-		// Bounce only rect1
-		body1.Velocity = body1.Velocity.Scale(-e)
-	} else if !body1.IsMovable && body2.IsMovable {
-		// Bounce only rect2
-		body2.Velocity = body2.Velocity.Scale(-e)
-	}
-	// No bounce if both are static
+// hasRestitution reports whether a body says how bouncy it is, through its
+// material or its own Restitution.
+func hasRestitution(rb *rigidbody.RigidBody) bool {
+	return rb.Material != nil || rb.Restitution != 0
+}
+
+// bounceOff returns the velocity of a dynamic body bouncing off a body that
+// contacts cannot move. A static body has zero velocity, a kinematic one
+// imparts its own velocity to whatever it hits.
+func bounceOff(v, other vector.Vector, e float64) vector.Vector {
+	return other.Add(v.Sub(other).Scale(-e))
 }
@@ -0,0 +1,30 @@
+package collision
+
+import (
+	"github.com/rudransh61/Physix-go/pkg/polygon"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+)
+
+// CollideCompound returns a manifold for every piece of the compound that
+// touches body. The normals point from the compound to body.
+func CollideCompound(c *polygon.Compound, body *rigidbody.RigidBody) []*Manifold {
+	return CollideFixtures(&c.RigidBody, body)
+}
+
+// CollideCompounds returns a manifold for every pair of touching pieces of
+// two compounds.
+func CollideCompounds(c1, c2 *polygon.Compound) []*Manifold {
+	return CollideFixtures(&c1.RigidBody, &c2.RigidBody)
+}
+
+// ResolveCompound pushes a compound and a body apart and changes their
+// velocities. It reports whether they touched.
+func ResolveCompound(c *polygon.Compound, body *rigidbody.RigidBody) bool {
+	return Resolve(&c.RigidBody, body)
+}
+
+// ResolveCompounds pushes two compounds apart and changes their velocities.
+// It reports whether they touched.
+func ResolveCompounds(c1, c2 *polygon.Compound) bool {
+	return Resolve(&c1.RigidBody, &c2.RigidBody)
+}
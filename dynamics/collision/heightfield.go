@@ -0,0 +1,260 @@
+package collision
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/pkg/material"
+	"github.com/rudransh61/Physix-go/pkg/polygon"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Heightfield is static terrain given by heights sampled at a fixed spacing.
+// Sample i sits at x = Origin.X + i*Spacing, Heights[i] above Origin.Y, and
+// the ground is solid below the line through the samples. Like a Chain, the
+// surface is one-sided and smooth across samples: contacts on the face of a
+// segment take the normals of its samples, interpolated along it, so bodies
+// roll over the joints without a kick.
+type Heightfield struct {
+	Origin  vector.Vector
+	Spacing float64
+	Heights []float64
+	normals []vector.Vector
+	body    rigidbody.RigidBody
+}
+
+// RaycastHit describes where a ray hits a surface.
+type RaycastHit struct {
+	Point    vector.Vector
+	Normal   vector.Vector // Unit surface normal facing the ray
+	Distance float64       // Distance from the ray origin to Point
+}
+
+// NewHeightfield creates terrain from heights sampled every spacing units,
+// starting at origin.
+func NewHeightfield(origin vector.Vector, spacing float64, heights []float64) *Heightfield {
+	h := &Heightfield{
+		Origin:  origin,
+		Spacing: spacing,
+		Heights: heights,
+		body:    rigidbody.RigidBody{Shape: "Heightfield", Type: rigidbody.Static},
+	}
+	h.updateNormals()
+	return h
+}
+
+// Body returns the static body that stands for the terrain in its manifolds.
+func (h *Heightfield) Body() *rigidbody.RigidBody {
+	return &h.body
+}
+
+// SetMaterial sets the material used for friction and restitution against the terrain.
+func (h *Heightfield) SetMaterial(m *material.Material) {
+	h.body.Material = m
+}
+
+// SetHeight changes sample i, for terrain that is dug or built up at runtime.
+func (h *Heightfield) SetHeight(i int, height float64) {
+	h.Heights[i] = height
+	h.updateNormals()
+}
+
+// Point returns the surface point of sample i.
+func (h *Heightfield) Point(i int) vector.Vector {
+	return vector.Vector{X: h.Origin.X + float64(i)*h.Spacing, Y: h.Origin.Y - h.Heights[i]}
+}
+
+// Normal returns the surface normal at sample i, averaged over the segments
+// on both sides of it.
+func (h *Heightfield) Normal(i int) vector.Vector {
+	return h.normals[i]
+}
+
+// SurfaceAt returns the surface point and interpolated normal at x. It reports
+// false when x is outside the terrain.
+func (h *Heightfield) SurfaceAt(x float64) (vector.Vector, vector.Vector, bool) {
+	if len(h.Heights) < 2 {
+		return vector.Vector{}, vector.Vector{}, false
+	}
+	f := (x - h.Origin.X) / h.Spacing
+	if f < 0 || f > float64(len(h.Heights)-1) {
+		return vector.Vector{}, vector.Vector{}, false
+	}
+	i := int(math.Min(math.Floor(f), float64(len(h.Heights)-2)))
+	t := f - float64(i)
+	a, b := h.Point(i), h.Point(i+1)
+	point := a.Add(b.Sub(a).Scale(t))
+	normal := h.normals[i].Scale(1 - t).Add(h.normals[i+1].Scale(t)).Normalize()
+	return point, normal, true
+}
+
+// Bounds returns the bounding box of the surface.
+func (h *Heightfield) Bounds() AABB {
+	box := AABB{Min: h.Point(0), Max: h.Point(0)}
+	for i := 1; i < len(h.Heights); i++ {
+		p := h.Point(i)
+		box = box.Union(AABB{Min: p, Max: p})
+	}
+	return box
+}
+
+// Collide returns a manifold for every terrain segment the body touches.
+// Body1 of each manifold is the terrain's Body and the normal points from the
+// terrain to body. Sensor fixtures of the body are left out.
+func (h *Heightfield) Collide(body *rigidbody.RigidBody) []*Manifold {
+	var manifolds []*Manifold
+	for _, s := range solidShapes(body) {
+		for _, m := range h.collide(body, s.convex.center(), s.convex, s.bounds()) {
+			m.Fixture2 = s.fixture
+			manifolds = append(manifolds, m)
+		}
+	}
+	return manifolds
+}
+
+// CollidePolygon returns a manifold for every terrain segment a convex polygon touches.
+func (h *Heightfield) CollidePolygon(p *polygon.Polygon) []*Manifold {
+	b := newConvex(p.WorldVertices(), 0)
+	return h.collide(&p.RigidBody, p.Position, b, boundsOfPoints(b.verts))
+}
+
+// Resolve pushes the body out of the terrain and changes its velocity. It
+// reports whether the body touched the terrain.
+func (h *Heightfield) Resolve(body *rigidbody.RigidBody) bool {
+	if resting(body) {
+		return false
+	}
+	manifolds := h.Collide(body)
+	Resolver{}.ResolveManifolds(manifolds)
+	return len(manifolds) > 0
+}
+
+// Raycast returns the first point where the ray from origin along direction
+// hits the surface from above, within maxDistance.
+func (h *Heightfield) Raycast(origin, direction vector.Vector, maxDistance float64) (RaycastHit, bool) {
+	if len(h.Heights) < 2 || direction.Magnitude() == 0 {
+		return RaycastHit{}, false
+	}
+	dir := direction.Normalize()
+	end := origin.Add(dir.Scale(maxDistance))
+	first, last := h.columns(math.Min(origin.X, end.X), math.Max(origin.X, end.X))
+
+	best := RaycastHit{Distance: math.Inf(1)}
+	for i := first; i < last; i++ {
+		a, b := h.Point(i), h.Point(i+1)
+		n := convex{verts: []vector.Vector{a, b}}.edgeNormal(0)
+		if n.InnerProduct(dir) >= 0 {
+			continue // Leaving the ground, or running along it
+		}
+		if d, ok := raySegment(origin, dir, a, b); ok && d <= maxDistance && d < best.Distance {
+			best = RaycastHit{Point: origin.Add(dir.Scale(d)), Normal: n, Distance: d}
+		}
+	}
+	return best, !math.IsInf(best.Distance, 1)
+}
+
+func (h *Heightfield) collide(body *rigidbody.RigidBody, center vector.Vector, b convex, bounds AABB) []*Manifold {
+	if len(h.Heights) < 2 {
+		return nil
+	}
+	first, last := h.columns(bounds.Min.X, bounds.Max.X)
+	var manifolds []*Manifold
+	for i := first; i < last; i++ {
+		v1, v2 := h.Point(i), h.Point(i+1)
+		if !boundsOfPoints([]vector.Vector{v1, v2}).Overlaps(bounds) {
+			continue
+		}
+		var v0, v3 vector.Vector
+		hasV0, hasV3 := i > 0, i+2 < len(h.Heights)
+		if hasV0 {
+			v0 = h.Point(i - 1)
+		}
+		if hasV3 {
+			v3 = h.Point(i + 2)
+		}
+		normal, depth, contacts, ok := collideEdge(v0, v1, v2, v3, hasV0, hasV3, center, b)
+		if !ok {
+			continue
+		}
+		if face := h.faceNormal(i); normal.InnerProduct(face) > 1-1e-6 {
+			normal, depth = h.smoothNormal(i, contacts, face, depth)
+		}
+		manifolds = append(manifolds, &Manifold{Body1: &h.body, Body2: body, Normal: normal, Depth: depth, Contacts: contacts})
+	}
+	return manifolds
+}
+
+// faceNormal returns the normal of segment i, from sample i to i+1.
+func (h *Heightfield) faceNormal(i int) vector.Vector {
+	return convex{verts: []vector.Vector{h.Point(i), h.Point(i + 1)}}.edgeNormal(0)
+}
+
+// smoothNormal turns a contact on the face of segment i into one along the
+// sample normals interpolated at the contacts, deepened so that pushing out
+// along it still clears the face.
+func (h *Heightfield) smoothNormal(i int, contacts []vector.Vector, face vector.Vector, depth float64) (vector.Vector, float64) {
+	if len(contacts) == 0 {
+		return face, depth
+	}
+	a, b := h.Point(i), h.Point(i+1)
+	var mid vector.Vector
+	for _, c := range contacts {
+		mid = mid.Add(c)
+	}
+	mid = mid.Scale(1 / float64(len(contacts)))
+	edge := b.Sub(a)
+	t := math.Max(0, math.Min(1, mid.Sub(a).InnerProduct(edge)/edge.InnerProduct(edge)))
+	normal := h.normals[i].Scale(1 - t).Add(h.normals[i+1].Scale(t))
+	if normal.Magnitude() == 0 {
+		return face, depth
+	}
+	normal = normal.Normalize()
+	cos := normal.InnerProduct(face)
+	if cos <= 0 {
+		return face, depth
+	}
+	return normal, depth / cos
+}
+
+// columns returns the range of segments [first, last) spanning minX to maxX.
+func (h *Heightfield) columns(minX, maxX float64) (int, int) {
+	first := int(math.Floor((minX - h.Origin.X) / h.Spacing))
+	last := int(math.Floor((maxX-h.Origin.X)/h.Spacing)) + 1
+	first = int(math.Max(float64(first), 0))
+	last = int(math.Min(float64(last), float64(len(h.Heights)-1)))
+	return first, last
+}
+
+func (h *Heightfield) updateNormals() {
+	h.normals = make([]vector.Vector, len(h.Heights))
+	for i := range h.Heights {
+		var n vector.Vector
+		if i > 0 {
+			n = n.Add(convex{verts: []vector.Vector{h.Point(i - 1), h.Point(i)}}.edgeNormal(0))
+		}
+		if i+1 < len(h.Heights) {
+			n = n.Add(convex{verts: []vector.Vector{h.Point(i), h.Point(i + 1)}}.edgeNormal(0))
+		}
+		if n.Magnitude() > 0 {
+			n = n.Normalize()
+		}
+		h.normals[i] = n
+	}
+}
+
+// raySegment returns the distance along the unit direction dir at which the
+// ray from origin crosses segment a-b.
+func raySegment(origin, dir, a, b vector.Vector) (float64, bool) {
+	e := b.Sub(a)
+	denom := dir.Cross(e)
+	if denom == 0 {
+		return 0, false
+	}
+	w := a.Sub(origin)
+	d := w.Cross(e) / denom
+	s := w.Cross(dir) / denom
+	if d < 0 || s < 0 || s > 1 {
+		return 0, false
+	}
+	return d, true
+}
@@ -0,0 +1,430 @@
+package collision
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/pkg/polygon"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Manifold describes how two bodies touch.
+type Manifold struct {
+	Body1, Body2       *rigidbody.RigidBody
+	Fixture1, Fixture2 *rigidbody.Fixture // The touching fixtures, nil for bodies without fixtures
+	Normal             vector.Vector      // Unit normal pointing from Body1 to Body2
+	Depth              float64            // How far the bodies overlap along Normal
+	Contacts           []vector.Vector    // World-space contact points, one or two
+	Impulse            float64            // Normal impulse applied when the manifold was resolved
+}
+
+// IsSensor reports whether either touching fixture is a sensor. Sensor
+// manifolds report an overlap and are never resolved.
+func (m *Manifold) IsSensor() bool {
+	return (m.Fixture1 != nil && m.Fixture1.IsSensor) || (m.Fixture2 != nil && m.Fixture2.IsSensor)
+}
+
+// Collide checks two bodies for contact and builds their manifold.
+// Circles, rectangles, capsules and segments of any orientation are supported.
+// Bodies made of fixtures are checked with CollideFixtures.
+func Collide(body1, body2 *rigidbody.RigidBody) (*Manifold, bool) {
+	a, okA := convexOf(body1)
+	b, okB := convexOf(body2)
+	if !okA || !okB {
+		return nil, false
+	}
+	return collideBodies(body1, body2, a, b)
+}
+
+// CollidePolygon checks a convex polygon against a circle, rectangle, capsule
+// or segment body. The normal points from the polygon to the body.
+func CollidePolygon(p *polygon.Polygon, body *rigidbody.RigidBody) (*Manifold, bool) {
+	b, ok := convexOf(body)
+	if !ok {
+		return nil, false
+	}
+	return collideBodies(&p.RigidBody, body, newConvex(p.WorldVertices(), 0), b)
+}
+
+// CollidePolygons checks two convex polygons for contact.
+func CollidePolygons(p1, p2 *polygon.Polygon) (*Manifold, bool) {
+	return collideBodies(&p1.RigidBody, &p2.RigidBody, newConvex(p1.WorldVertices(), 0), newConvex(p2.WorldVertices(), 0))
+}
+
+// CollideFixtures returns a manifold for every pair of touching fixtures of
+// two bodies whose filters let them collide, sensors included. A body without
+// fixtures takes part as a single fixture with the zero Filter.
+func CollideFixtures(body1, body2 *rigidbody.RigidBody) []*Manifold {
+	shapes2 := shapesOf(body2)
+	var manifolds []*Manifold
+	for _, s1 := range shapesOf(body1) {
+		for _, s2 := range shapes2 {
+			if !rigidbody.ShouldCollide(s1.filter(), s2.filter()) || !s1.bounds().Overlaps(s2.bounds()) {
+				continue
+			}
+			if m, ok := collideBodies(body1, body2, s1.convex, s2.convex); ok {
+				m.Fixture1, m.Fixture2 = s1.fixture, s2.fixture
+				manifolds = append(manifolds, m)
+			}
+		}
+	}
+	return manifolds
+}
+
+func collideBodies(body1, body2 *rigidbody.RigidBody, a, b convex) (*Manifold, bool) {
+	normal, depth, contacts, ok := collideConvex(a, b)
+	if !ok {
+		return nil, false
+	}
+	return &Manifold{Body1: body1, Body2: body2, Normal: normal, Depth: depth, Contacts: contacts}, true
+}
+
+// convex is a convex polygon grown by a radius. A circle is a single point
+// with a radius, a capsule is a segment with a radius and a rectangle is four
+// corners with no radius.
+type convex struct {
+	verts  []vector.Vector
+	radius float64
+}
+
+// convexOf returns the world-space convex shape of a body.
+func convexOf(rb *rigidbody.RigidBody) (convex, bool) {
+	switch rb.Shape {
+	case "Circle":
+		return convex{verts: []vector.Vector{rb.Position}, radius: rb.Radius}, true
+	case "Rectangle":
+		return convex{verts: rb.Corners()}, true
+	case "Capsule", "Segment":
+		a, b := rb.SegmentEnds()
+		return convex{verts: []vector.Vector{a, b}, radius: rb.Radius}, true
+	}
+	return convex{}, false
+}
+
+// bodyShape is one convex shape of a body: the body itself, or one of its fixtures.
+type bodyShape struct {
+	convex  convex
+	fixture *rigidbody.Fixture
+}
+
+func (s bodyShape) filter() rigidbody.Filter {
+	if s.fixture == nil {
+		return rigidbody.Filter{}
+	}
+	return s.fixture.Filter
+}
+
+func (s bodyShape) bounds() AABB {
+	return boundsOfPoints(s.convex.verts).Expand(s.convex.radius)
+}
+
+// shapesOf returns the world-space convex shapes of a body.
+func shapesOf(rb *rigidbody.RigidBody) []bodyShape {
+	if len(rb.Fixtures) == 0 {
+		if c, ok := convexOf(rb); ok {
+			return []bodyShape{{convex: c}}
+		}
+		return nil
+	}
+	shapes := make([]bodyShape, 0, len(rb.Fixtures))
+	for _, f := range rb.Fixtures {
+		if c, ok := fixtureConvex(rb, f); ok {
+			shapes = append(shapes, bodyShape{convex: c, fixture: f})
+		}
+	}
+	return shapes
+}
+
+// fixtureConvex returns the world-space convex shape of a fixture.
+func fixtureConvex(rb *rigidbody.RigidBody, f *rigidbody.Fixture) (convex, bool) {
+	t := f.Transform(rb)
+	switch f.Shape {
+	case "Polygon":
+		verts := make([]vector.Vector, len(f.Vertices))
+		for i, v := range f.Vertices {
+			verts[i] = t.Apply(v)
+		}
+		return newConvex(verts, 0), true
+	case "Circle", "Rectangle", "Capsule", "Segment":
+		// Place a body of the same shape where the fixture is
+		probe := rigidbody.RigidBody{Position: t.Position, Angle: t.Angle, Shape: f.Shape, Width: f.Width, Height: f.Height, Radius: f.Radius}
+		return convexOf(&probe)
+	}
+	return convex{}, false
+}
+
+// center returns the average of the vertices of the shape.
+func (c convex) center() vector.Vector {
+	var sum vector.Vector
+	for _, v := range c.verts {
+		sum = sum.Add(v)
+	}
+	return sum.Scale(1 / float64(len(c.verts)))
+}
+
+// newConvex builds a convex shape from polygon vertices in either winding.
+// Polygons are stored counter-clockwise so edge normals point outwards.
+func newConvex(verts []vector.Vector, radius float64) convex {
+	if len(verts) > 2 && signedArea(verts) < 0 {
+		reversed := make([]vector.Vector, len(verts))
+		for i, v := range verts {
+			reversed[len(verts)-1-i] = v
+		}
+		verts = reversed
+	}
+	return convex{verts: verts, radius: radius}
+}
+
+func signedArea(verts []vector.Vector) float64 {
+	area := 0.0
+	for i := range verts {
+		area += verts[i].Cross(verts[(i+1)%len(verts)])
+	}
+	return area / 2
+}
+
+// edgeNormal returns the outward unit normal of edge i.
+func (c convex) edgeNormal(i int) vector.Vector {
+	e := c.verts[(i+1)%len(c.verts)].Sub(c.verts[i])
+	return vector.Vector{X: e.Y, Y: -e.X}.Normalize()
+}
+
+// axes returns the separating axes to test for the shape: its edge normals,
+// plus its direction when the shape is a segment.
+func (c convex) axes() []vector.Vector {
+	if len(c.verts) < 2 {
+		return nil
+	}
+	if len(c.verts) == 2 {
+		d := c.verts[1].Sub(c.verts[0]).Normalize()
+		return []vector.Vector{vector.Orthogonal(d), d}
+	}
+	axes := make([]vector.Vector, len(c.verts))
+	for i := range c.verts {
+		axes[i] = c.edgeNormal(i)
+	}
+	return axes
+}
+
+func (c convex) project(axis vector.Vector) (float64, float64) {
+	min := axis.InnerProduct(c.verts[0])
+	max := min
+	for _, v := range c.verts[1:] {
+		d := axis.InnerProduct(v)
+		min = math.Min(min, d)
+		max = math.Max(max, d)
+	}
+	return min, max
+}
+
+// collideConvex finds the contact between two convex shapes. The normal
+// points from a to b.
+func collideConvex(a, b convex) (vector.Vector, float64, []vector.Vector, bool) {
+	radius := a.radius + b.radius
+
+	// Separating axis test on the cores. The axis with the largest separation
+	// is kept; it is the contact normal when the cores overlap.
+	bestSep := math.Inf(-1)
+	var bestAxis vector.Vector
+	for _, axis := range append(a.axes(), b.axes()...) {
+		minA, maxA := a.project(axis)
+		minB, maxB := b.project(axis)
+		sep, dir := minB-maxA, axis
+		if s := minA - maxB; s > sep {
+			sep, dir = s, axis.Scale(-1)
+		}
+		if sep-radius >= 0 {
+			return vector.Vector{}, 0, nil, false
+		}
+		if sep > bestSep {
+			bestSep, bestAxis = sep, dir
+		}
+	}
+
+	if bestSep > 0 || math.IsInf(bestSep, -1) {
+		// The cores are apart: only the radii overlap
+		pA, pB := closestPoints(a, b)
+		d := vector.Distance(pA, pB)
+		if d >= radius {
+			return vector.Vector{}, 0, nil, false
+		}
+		normal := vector.Vector{Y: 1} // Any direction will do for coincident centres
+		if d > 0 {
+			normal = pB.Sub(pA).Scale(1 / d)
+		}
+		depth := radius - d
+		contacts := clipContacts(a, b, normal, true)
+		if len(contacts) == 0 {
+			mid := pA.Add(normal.Scale(a.radius)).Add(pB.Sub(normal.Scale(b.radius))).Scale(0.5)
+			contacts = []vector.Vector{mid}
+		}
+		return normal, depth, contacts, true
+	}
+
+	// The cores overlap: push out along the axis of least penetration
+	normal := bestAxis
+	depth := radius - bestSep
+	contacts := clipContacts(a, b, normal, false)
+	if len(contacts) == 0 {
+		contacts = []vector.Vector{deepestPoint(a, b, normal)}
+	}
+	return normal, depth, contacts, true
+}
+
+// clipContacts finds up to two contact points by clipping the incident edge of
+// one shape against the reference edge of the other. When onlyFaces is set,
+// contacts are only built for faces lying flat against each other.
+func clipContacts(a, b convex, normal vector.Vector, onlyFaces bool) []vector.Vector {
+	if len(a.verts) < 2 || len(b.verts) < 2 {
+		return nil
+	}
+	refA, alignA := bestEdge(a, normal)
+	refB, alignB := bestEdge(b, normal.Scale(-1))
+	ref, inc, refEdge, n := a, b, refA, normal
+	align := alignA
+	if alignB > alignA+0.001 {
+		ref, inc, refEdge, n = b, a, refB, normal.Scale(-1)
+		align = alignB
+	}
+	incEdge, incAlign := bestEdge(inc, n.Scale(-1))
+	if onlyFaces && (align < 0.999 || incAlign < 0.999) {
+		return nil
+	}
+
+	r1 := ref.verts[refEdge]
+	r2 := ref.verts[(refEdge+1)%len(ref.verts)]
+	refNormal := ref.edgeNormal(refEdge)
+	if len(ref.verts) == 2 && refNormal.InnerProduct(n) < 0 {
+		refNormal = refNormal.Scale(-1)
+	}
+	tangent := r2.Sub(r1).Normalize()
+	i1 := inc.verts[incEdge]
+	i2 := inc.verts[(incEdge+1)%len(inc.verts)]
+
+	// Keep the part of the incident edge that lies beside the reference edge
+	lo, hi := tangent.InnerProduct(r1), tangent.InnerProduct(r2)
+	i1, i2, ok := clipSegment(i1, i2, tangent, lo, hi)
+	if !ok {
+		return nil
+	}
+
+	var contacts []vector.Vector
+	for _, p := range []vector.Vector{i1, i2} {
+		s := p.Sub(r1).InnerProduct(refNormal)
+		if s >= ref.radius+inc.radius {
+			continue
+		}
+		// Halfway between the two surfaces
+		contacts = append(contacts, p.Add(refNormal.Scale((ref.radius-inc.radius-s)/2)))
+	}
+	if len(contacts) == 2 && vector.Distance(contacts[0], contacts[1]) < 1e-9 {
+		contacts = contacts[:1]
+	}
+	return contacts
+}
+
+// bestEdge returns the edge whose outward normal is closest to dir and how
+// closely it lines up. Both sides of a segment are considered.
+func bestEdge(c convex, dir vector.Vector) (int, float64) {
+	if len(c.verts) == 2 {
+		n := c.edgeNormal(0)
+		return 0, math.Abs(n.InnerProduct(dir))
+	}
+	best, bestDot := 0, math.Inf(-1)
+	for i := range c.verts {
+		if d := c.edgeNormal(i).InnerProduct(dir); d > bestDot {
+			best, bestDot = i, d
+		}
+	}
+	return best, bestDot
+}
+
+// clipSegment clips the segment p1-p2 to lo <= p·axis <= hi.
+func clipSegment(p1, p2, axis vector.Vector, lo, hi float64) (vector.Vector, vector.Vector, bool) {
+	d1, d2 := axis.InnerProduct(p1), axis.InnerProduct(p2)
+	if d1 > d2 {
+		p1, p2, d1, d2 = p2, p1, d2, d1
+	}
+	if d2 < lo || d1 > hi {
+		return p1, p2, false
+	}
+	lerp := func(t float64) vector.Vector { return p1.Add(p2.Sub(p1).Scale(t)) }
+	q1, q2 := p1, p2
+	if d2 > d1 {
+		if d1 < lo {
+			q1 = lerp((lo - d1) / (d2 - d1))
+		}
+		if d2 > hi {
+			q2 = lerp((hi - d1) / (d2 - d1))
+		}
+	}
+	return q1, q2, true
+}
+
+// deepestPoint returns the point of one shape that reaches furthest into the
+// other along the normal. It is used when clipping finds no contact, such as
+// a circle whose centre is inside a box.
+func deepestPoint(a, b convex, normal vector.Vector) vector.Vector {
+	if len(a.verts) == 1 {
+		return a.verts[0]
+	}
+	if len(b.verts) == 1 {
+		return b.verts[0]
+	}
+	best := b.verts[0]
+	for _, v := range b.verts[1:] {
+		if v.InnerProduct(normal) < best.InnerProduct(normal) {
+			best = v
+		}
+	}
+	return best
+}
+
+// closestPoints returns the closest pair of points between the cores of two
+// shapes that do not overlap.
+func closestPoints(a, b convex) (vector.Vector, vector.Vector) {
+	bestDist := math.Inf(1)
+	var pA, pB vector.Vector
+	try := func(p, q vector.Vector) {
+		if d := vector.Distance(p, q); d < bestDist {
+			bestDist, pA, pB = d, p, q
+		}
+	}
+	for _, v := range a.verts {
+		for _, e := range edges(b) {
+			try(v, closestOnSegment(v, e[0], e[1]))
+		}
+	}
+	for _, v := range b.verts {
+		for _, e := range edges(a) {
+			try(closestOnSegment(v, e[0], e[1]), v)
+		}
+	}
+	return pA, pB
+}
+
+// edges returns the edges of a shape; a single point is a zero-length edge.
+func edges(c convex) [][2]vector.Vector {
+	if len(c.verts) == 1 {
+		return [][2]vector.Vector{{c.verts[0], c.verts[0]}}
+	}
+	if len(c.verts) == 2 {
+		return [][2]vector.Vector{{c.verts[0], c.verts[1]}}
+	}
+	out := make([][2]vector.Vector, len(c.verts))
+	for i := range c.verts {
+		out[i] = [2]vector.Vector{c.verts[i], c.verts[(i+1)%len(c.verts)]}
+	}
+	return out
+}
+
+// closestOnSegment returns the point of segment a-b closest to p.
+func closestOnSegment(p, a, b vector.Vector) vector.Vector {
+	ab := b.Sub(a)
+	lengthSquared := ab.InnerProduct(ab)
+	if lengthSquared == 0 {
+		return a
+	}
+	t := math.Max(0, math.Min(1, p.Sub(a).InnerProduct(ab)/lengthSquared))
+	return a.Add(ab.Scale(t))
+}
@@ -0,0 +1,276 @@
+package collision
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/pkg/material"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Resolver resolves contacts like ResolveCollision, Resolve and
+// ApplyImpulses, but looks up the friction and restitution of chosen material
+// pairs in its own table first. Keep one with the world or solver that uses
+// the table. The zero Resolver has no overrides, like the package functions.
+type Resolver struct {
+	Materials *material.Table // Per-pair overrides, nil for none
+}
+
+// MixMaterials returns the friction and restitution used between two bodies,
+// mixed with each material's combine modes.
+func MixMaterials(body1, body2 *rigidbody.RigidBody) material.Pair {
+	return Resolver{}.Mix(body1, body2)
+}
+
+// Mix returns the friction and restitution used between two bodies: the
+// override for their materials if there is one, and MixMaterials otherwise.
+func (r Resolver) Mix(body1, body2 *rigidbody.RigidBody) material.Pair {
+	if p, ok := r.Materials.Override(body1.Material, body2.Material); ok {
+		return p
+	}
+	return material.Mix(body1.Surface(), body2.Surface())
+}
+
+// mixManifold returns the friction and restitution used at a manifold. A
+// fixture with its own material uses it instead of the body's.
+func mixManifold(m *Manifold, table *material.Table) material.Pair {
+	a, b := materialOf(m.Body1, m.Fixture1), materialOf(m.Body2, m.Fixture2)
+	if p, ok := table.Override(a, b); ok {
+		return p
+	}
+	if a == nil {
+		a = m.Body1.Surface()
+	}
+	if b == nil {
+		b = m.Body2.Surface()
+	}
+	return material.Mix(a, b)
+}
+
+func materialOf(rb *rigidbody.RigidBody, f *rigidbody.Fixture) *material.Material {
+	if f != nil && f.Material != nil {
+		return f.Material
+	}
+	return rb.Material
+}
+
+// solid leaves out the sensor manifolds.
+func solid(manifolds []*Manifold) []*Manifold {
+	out := manifolds[:0]
+	for _, m := range manifolds {
+		if !m.IsSensor() {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+var (
+	PenetrationSlop      float64 = 0.01 // Overlap left in place by Resolve so resting contacts persist
+	CorrectionPercent    float64 = 0.8  // Share of the remaining overlap removed by Resolve each step
+	RestitutionThreshold float64 = 1.0  // Impacts slower than this do not bounce, so resting bodies settle
+	SolverIterations     int     = 6    // Passes ApplyImpulses makes over the contacts of a manifold
+)
+
+// ResolveCollision changes the velocities of two touching bodies using the
+// restitution and friction of their materials. Unlike BounceOnCollision it only
+// acts while the bodies approach each other, it slows down sliding and it
+// spins bodies hit off their centre.
+func ResolveCollision(body1, body2 *rigidbody.RigidBody) {
+	Resolver{}.ResolveCollision(body1, body2)
+}
+
+// ResolveCollision is ResolveCollision with the resolver's overrides.
+func (r Resolver) ResolveCollision(body1, body2 *rigidbody.RigidBody) {
+	if atRest(body1, body2) {
+		return
+	}
+	if len(body1.Fixtures) > 0 || len(body2.Fixtures) > 0 {
+		manifolds := solid(CollideFixtures(body1, body2))
+		if len(manifolds) > 0 {
+			wakePair(body1, body2)
+			solveContacts(manifolds, r.Materials)
+		}
+		return
+	}
+	m, ok := Collide(body1, body2)
+	if !ok {
+		return
+	}
+	wakePair(body1, body2)
+	r.ApplyImpulses(m)
+}
+
+// Resolve checks two bodies for contact and, if they touch, pushes them apart
+// and changes their velocities. It reports whether the bodies touched.
+// Bodies made of fixtures are resolved at every pair of touching fixtures,
+// leaving out sensors.
+func Resolve(body1, body2 *rigidbody.RigidBody) bool {
+	return Resolver{}.Resolve(body1, body2)
+}
+
+// Resolve is Resolve with the resolver's overrides.
+func (r Resolver) Resolve(body1, body2 *rigidbody.RigidBody) bool {
+	if atRest(body1, body2) {
+		return false
+	}
+	if len(body1.Fixtures) > 0 || len(body2.Fixtures) > 0 {
+		manifolds := solid(CollideFixtures(body1, body2))
+		r.ResolveManifolds(manifolds)
+		return len(manifolds) > 0
+	}
+	m, ok := Collide(body1, body2)
+	if !ok {
+		return false
+	}
+	wakePair(body1, body2)
+	r.ApplyImpulses(m)
+	Separate(m, CorrectionPercent, PenetrationSlop)
+	return true
+}
+
+// Separate moves the bodies of a manifold apart along its normal by percent of
+// their overlap beyond slop, each by its share of the total inverse mass.
+func Separate(m *Manifold, percent, slop float64) {
+	depth := math.Max(m.Depth-slop, 0) * percent
+	share1, share2 := massShares(m.Body1, m.Body2)
+	m.Body1.Position = m.Body1.Position.Sub(m.Normal.Scale(depth * share1))
+	m.Body2.Position = m.Body2.Position.Add(m.Normal.Scale(depth * share2))
+}
+
+// ResolveManifolds solves several manifolds between the same two bodies,
+// such as a body touching two edges of a chain at a joint, like the Resolve
+// methods of TileMap, Chain and Heightfield. Their contacts are solved
+// together, and each push out lowers the overlap left in the other manifolds
+// so the bodies are not pushed apart twice.
+func (r Resolver) ResolveManifolds(manifolds []*Manifold) {
+	for _, m := range manifolds {
+		wakePair(m.Body1, m.Body2)
+	}
+	solveContacts(manifolds, r.Materials)
+	for i, m := range manifolds {
+		before1, before2 := m.Body1.Position, m.Body2.Position
+		Separate(m, CorrectionPercent, PenetrationSlop)
+		moved := m.Body2.Position.Sub(before2).Sub(m.Body1.Position.Sub(before1))
+		for _, other := range manifolds[i+1:] {
+			other.Depth -= moved.InnerProduct(other.Normal)
+		}
+	}
+}
+
+// ApplyImpulses applies normal impulses with restitution and Coulomb friction
+// impulses at the contact points of the manifold. The contacts are solved
+// together over SolverIterations passes, so a box resting on two corners gets
+// an even push from both.
+// Kinematic bodies have an inverse mass of 0 but their velocity still enters
+// the relative velocity, so they drag dynamic bodies along.
+func ApplyImpulses(m *Manifold) {
+	Resolver{}.ApplyImpulses(m)
+}
+
+// ApplyImpulses is ApplyImpulses with the resolver's overrides.
+func (r Resolver) ApplyImpulses(m *Manifold) {
+	solveContacts([]*Manifold{m}, r.Materials)
+}
+
+// solveContacts applies impulses at the contacts of manifolds between the
+// same two bodies, solving all of them together, with the overrides in table.
+func solveContacts(manifolds []*Manifold, table *material.Table) {
+	if len(manifolds) == 0 {
+		return
+	}
+	body1, body2 := manifolds[0].Body1, manifolds[0].Body2
+	im1, im2 := body1.InverseMass(), body2.InverseMass()
+	ii1, ii2 := body1.InverseInertia(), body2.InverseInertia()
+	if im1+im2 == 0 {
+		return
+	}
+	type point struct {
+		r1, r2          vector.Vector
+		normal, tangent vector.Vector
+		massN, massT    float64 // Inverse of the effective mass along normal and tangent
+		bounce          float64 // Normal velocity the contact should separate with
+		jn, jt          float64 // Impulses accumulated over the iterations
+		mix             material.Pair
+		manifold        *Manifold
+	}
+	var points []point
+	for _, m := range manifolds {
+		mix := mixManifold(m, table)
+		normal := m.Normal
+		tangent := vector.Orthogonal(normal)
+		for _, c := range m.Contacts {
+			p := point{r1: c.Sub(body1.Position), r2: c.Sub(body2.Position), normal: normal, tangent: tangent, mix: mix, manifold: m}
+			rn1, rn2 := p.r1.Cross(normal), p.r2.Cross(normal)
+			rt1, rt2 := p.r1.Cross(tangent), p.r2.Cross(tangent)
+			p.massN = im1 + im2 + rn1*rn1*ii1 + rn2*rn2*ii2
+			p.massT = im1 + im2 + rt1*rt1*ii1 + rt2*rt2*ii2
+			vn := relativeVelocity(body1, body2, p.r1, p.r2).InnerProduct(normal)
+			if -vn > RestitutionThreshold {
+				p.bounce = -mix.Restitution * vn
+			}
+			points = append(points, p)
+		}
+	}
+
+	for iteration := 0; iteration < SolverIterations; iteration++ {
+		for i := range points {
+			p := &points[i]
+
+			// Normal impulse, never pulling the bodies together
+			vn := relativeVelocity(body1, body2, p.r1, p.r2).InnerProduct(p.normal)
+			jn := math.Max(p.jn+(p.bounce-vn)/p.massN, 0)
+			applyImpulse(body1, body2, p.r1, p.r2, p.normal.Scale(jn-p.jn), im1, im2, ii1, ii2)
+			p.jn = jn
+
+			// Friction impulse, limited by the normal impulse
+			vt := relativeVelocity(body1, body2, p.r1, p.r2).InnerProduct(p.tangent)
+			jt := p.jt - vt/p.massT
+			if math.Abs(jt) > p.mix.StaticFriction*p.jn {
+				jt = math.Copysign(p.mix.DynamicFriction*p.jn, jt)
+			}
+			applyImpulse(body1, body2, p.r1, p.r2, p.tangent.Scale(jt-p.jt), im1, im2, ii1, ii2)
+			p.jt = jt
+		}
+	}
+
+	for _, m := range manifolds {
+		m.Impulse = 0
+	}
+	for _, p := range points {
+		p.manifold.Impulse += p.jn
+		applyRollingResistance(body1, p.jn, p.mix.RollingResistance)
+		applyRollingResistance(body2, p.jn, p.mix.RollingResistance)
+	}
+}
+
+// relativeVelocity returns the velocity of body2 relative to body1 at a
+// contact point given by its offsets from both centres.
+func relativeVelocity(body1, body2 *rigidbody.RigidBody, r1, r2 vector.Vector) vector.Vector {
+	v1 := body1.Velocity.Add(vector.CrossScalar(body1.AngularVelocity, r1))
+	v2 := body2.Velocity.Add(vector.CrossScalar(body2.AngularVelocity, r2))
+	return v2.Sub(v1)
+}
+
+// applyImpulse applies impulse to body2 and its opposite to body1 at the
+// contact offsets r1 and r2.
+func applyImpulse(body1, body2 *rigidbody.RigidBody, r1, r2, impulse vector.Vector, im1, im2, ii1, ii2 float64) {
+	body1.Velocity = body1.Velocity.Sub(impulse.Scale(im1))
+	body1.AngularVelocity -= r1.Cross(impulse) * ii1
+	body2.Velocity = body2.Velocity.Add(impulse.Scale(im2))
+	body2.AngularVelocity += r2.Cross(impulse) * ii2
+}
+
+// applyRollingResistance slows down a rolling circle by a torque proportional
+// to the normal impulse j.
+func applyRollingResistance(rb *rigidbody.RigidBody, j, resistance float64) {
+	if rb.Shape != "Circle" || resistance == 0 || rb.AngularVelocity == 0 {
+		return
+	}
+	change := resistance * j * rb.Radius * rb.InverseInertia()
+	if change >= math.Abs(rb.AngularVelocity) {
+		rb.AngularVelocity = 0
+		return
+	}
+	rb.AngularVelocity -= math.Copysign(change, rb.AngularVelocity)
+}
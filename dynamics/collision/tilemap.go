@@ -0,0 +1,425 @@
+package collision
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/pkg/material"
+	"github.com/rudransh61/Physix-go/pkg/polygon"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Tile is the kind of a tile map cell.
+type Tile int
+
+const (
+	Empty     Tile = iota
+	Solid          // Blocks from every side
+	OneWay         // Platform that blocks from above only
+	SlopeUp        // Solid below the diagonal rising to the right
+	SlopeDown      // Solid below the diagonal falling to the right
+)
+
+// face is one side of a tile.
+type face int
+
+const (
+	top face = iota
+	right
+	bottom
+	left
+	diagonal
+)
+
+type gridPoint struct{ X, Y int }
+
+type faceKey struct {
+	X, Y int
+	Face face
+}
+
+// tileSegment is a run of collinear tile faces, directed so that its edge
+// normal points out of the solid.
+type tileSegment struct {
+	a, b  gridPoint
+	faces []faceKey
+	alive bool
+}
+
+// TileMap is static geometry built from a grid of tiles. The outline of the
+// solid tiles is merged into long one-sided edges, so bodies slide along rows
+// of tiles without catching on the seams, and only the outline near a body is
+// tested. Changing a tile rebuilds only the edges around it.
+type TileMap struct {
+	Origin        vector.Vector // World position of the top-left corner of tile (0, 0)
+	TileSize      float64
+	Width, Height int // Size of the map in tiles
+	tiles         []Tile
+	segments      []tileSegment
+	free          []int // Slots of removed segments, reused by new ones
+	byFace        map[faceKey]int
+	startAt       map[gridPoint][]int
+	endAt         map[gridPoint][]int
+	body          rigidbody.RigidBody
+}
+
+// NewTileMap creates a tile map from rows of tiles, tiles[y][x], with the top
+// row first.
+func NewTileMap(origin vector.Vector, tileSize float64, tiles [][]Tile) *TileMap {
+	t := &TileMap{
+		Origin:   origin,
+		TileSize: tileSize,
+		Height:   len(tiles),
+		body:     rigidbody.RigidBody{Shape: "TileMap", Type: rigidbody.Static},
+	}
+	for _, row := range tiles {
+		if len(row) > t.Width {
+			t.Width = len(row)
+		}
+	}
+	t.tiles = make([]Tile, t.Width*t.Height)
+	for y, row := range tiles {
+		copy(t.tiles[y*t.Width:], row)
+	}
+	t.Rebuild()
+	return t
+}
+
+// Body returns the static body that stands for the tile map in its manifolds.
+func (t *TileMap) Body() *rigidbody.RigidBody {
+	return &t.body
+}
+
+// SetMaterial sets the material used for friction and restitution against the tiles.
+func (t *TileMap) SetMaterial(m *material.Material) {
+	t.body.Material = m
+}
+
+// Tile returns the tile at x, y. Cells outside the map are Empty.
+func (t *TileMap) Tile(x, y int) Tile {
+	if x < 0 || y < 0 || x >= t.Width || y >= t.Height {
+		return Empty
+	}
+	return t.tiles[y*t.Width+x]
+}
+
+// SetTile changes the tile at x, y and rebuilds the edges around it.
+func (t *TileMap) SetTile(x, y int, tile Tile) {
+	if x < 0 || y < 0 || x >= t.Width || y >= t.Height || t.Tile(x, y) == tile {
+		return
+	}
+	t.tiles[y*t.Width+x] = tile
+
+	// Every face of the tile and its neighbours may have changed, along with
+	// the rest of any edge running through them
+	var dirty []faceKey
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			for f := top; f <= diagonal; f++ {
+				k := faceKey{x + dx, y + dy, f}
+				if id, ok := t.byFace[k]; ok {
+					dirty = append(dirty, t.segments[id].faces...)
+					t.removeSegment(id)
+				}
+				dirty = append(dirty, k)
+			}
+		}
+	}
+	t.addEdges(dirty)
+}
+
+// Rebuild merges the outline of the whole map again. SetTile keeps the
+// outline merged, so Rebuild is only needed to start over.
+func (t *TileMap) Rebuild() {
+	t.segments = nil
+	t.free = nil
+	t.byFace = make(map[faceKey]int)
+	t.startAt = make(map[gridPoint][]int)
+	t.endAt = make(map[gridPoint][]int)
+	var all []faceKey
+	for y := 0; y < t.Height; y++ {
+		for x := 0; x < t.Width; x++ {
+			for f := top; f <= diagonal; f++ {
+				all = append(all, faceKey{x, y, f})
+			}
+		}
+	}
+	t.addEdges(all)
+}
+
+// Edges returns the merged outline as world-space segments, for drawing.
+func (t *TileMap) Edges() [][2]vector.Vector {
+	var out [][2]vector.Vector
+	for _, s := range t.segments {
+		if s.alive {
+			out = append(out, [2]vector.Vector{t.world(s.a), t.world(s.b)})
+		}
+	}
+	return out
+}
+
+// Collide returns a manifold for every outline edge the body touches.
+// Body1 of each manifold is the tile map's Body and the normal points from
+// the tiles to body. Sensor fixtures of the body are left out.
+func (t *TileMap) Collide(body *rigidbody.RigidBody) []*Manifold {
+	var manifolds []*Manifold
+	for _, s := range solidShapes(body) {
+		for _, m := range t.collide(body, s.convex.center(), s.convex, s.bounds()) {
+			m.Fixture2 = s.fixture
+			manifolds = append(manifolds, m)
+		}
+	}
+	return manifolds
+}
+
+// CollidePolygon returns a manifold for every outline edge a convex polygon touches.
+func (t *TileMap) CollidePolygon(p *polygon.Polygon) []*Manifold {
+	b := newConvex(p.WorldVertices(), 0)
+	return t.collide(&p.RigidBody, p.Position, b, boundsOfPoints(b.verts))
+}
+
+// Resolve pushes the body out of the tiles and changes its velocity. It
+// reports whether the body touched the tiles.
+func (t *TileMap) Resolve(body *rigidbody.RigidBody) bool {
+	if resting(body) {
+		return false
+	}
+	manifolds := t.Collide(body)
+	Resolver{}.ResolveManifolds(manifolds)
+	return len(manifolds) > 0
+}
+
+func (t *TileMap) collide(body *rigidbody.RigidBody, center vector.Vector, b convex, bounds AABB) []*Manifold {
+	x0 := int(math.Floor((bounds.Min.X-t.Origin.X)/t.TileSize)) - 1
+	y0 := int(math.Floor((bounds.Min.Y-t.Origin.Y)/t.TileSize)) - 1
+	x1 := int(math.Floor((bounds.Max.X-t.Origin.X)/t.TileSize)) + 1
+	y1 := int(math.Floor((bounds.Max.Y-t.Origin.Y)/t.TileSize)) + 1
+
+	seen := make(map[int]bool)
+	var manifolds []*Manifold
+	for y := max(y0, 0); y <= min(y1, t.Height-1); y++ {
+		for x := max(x0, 0); x <= min(x1, t.Width-1); x++ {
+			for f := top; f <= diagonal; f++ {
+				id, ok := t.byFace[faceKey{x, y, f}]
+				if !ok || seen[id] {
+					continue
+				}
+				seen[id] = true
+				s := t.segments[id]
+				v1, v2 := t.world(s.a), t.world(s.b)
+				if !boundsOfPoints([]vector.Vector{v1, v2}).Overlaps(bounds) {
+					continue
+				}
+				v0, hasV0 := t.ghost(t.endAt[s.a], id, true)
+				v3, hasV3 := t.ghost(t.startAt[s.b], id, false)
+				normal, depth, contacts, ok := collideEdge(v0, v1, v2, v3, hasV0, hasV3, center, b)
+				if !ok {
+					continue
+				}
+				manifolds = append(manifolds, &Manifold{Body1: &t.body, Body2: body, Normal: normal, Depth: depth, Contacts: contacts})
+			}
+		}
+	}
+	return manifolds
+}
+
+// ghost returns the far end of a segment joining segment id, which serves as
+// its ghost vertex.
+func (t *TileMap) ghost(ids []int, id int, before bool) (vector.Vector, bool) {
+	for _, other := range ids {
+		if other == id || !t.segments[other].alive {
+			continue
+		}
+		if before {
+			return t.world(t.segments[other].a), true
+		}
+		return t.world(t.segments[other].b), true
+	}
+	return vector.Vector{}, false
+}
+
+func (t *TileMap) world(p gridPoint) vector.Vector {
+	return vector.Vector{X: t.Origin.X + float64(p.X)*t.TileSize, Y: t.Origin.Y + float64(p.Y)*t.TileSize}
+}
+
+// addEdges merges the given faces that lie on the outline into segments.
+func (t *TileMap) addEdges(faces []faceKey) {
+	type unit struct {
+		a, b gridPoint
+		key  faceKey
+	}
+	var units []unit
+	starts := make(map[gridPoint][]int)
+	ends := make(map[gridPoint][]int)
+	added := make(map[faceKey]bool)
+	for _, k := range faces {
+		if added[k] {
+			continue
+		}
+		added[k] = true
+		if _, ok := t.byFace[k]; ok {
+			continue
+		}
+		a, b, ok := t.outlineFace(k)
+		if !ok {
+			continue
+		}
+		starts[a] = append(starts[a], len(units))
+		ends[b] = append(ends[b], len(units))
+		units = append(units, unit{a, b, k})
+	}
+
+	// follow returns the unit continuing u in the same direction, if any
+	used := make([]bool, len(units))
+	follow := func(u int, next map[gridPoint][]int, at gridPoint) int {
+		d := gridPoint{units[u].b.X - units[u].a.X, units[u].b.Y - units[u].a.Y}
+		for _, v := range next[at] {
+			dv := gridPoint{units[v].b.X - units[v].a.X, units[v].b.Y - units[v].a.Y}
+			if !used[v] && dv == d {
+				return v
+			}
+		}
+		return -1
+	}
+	for i := range units {
+		if used[i] {
+			continue
+		}
+		first := i
+		for p := follow(first, ends, units[first].a); p >= 0 && p != i; p = follow(first, ends, units[first].a) {
+			first = p
+		}
+		s := tileSegment{a: units[first].a, alive: true}
+		for u := first; u >= 0; u = follow(u, starts, units[u].b) {
+			used[u] = true
+			s.b = units[u].b
+			s.faces = append(s.faces, units[u].key)
+		}
+		t.addSegment(t.join(s))
+	}
+}
+
+// join merges a new segment with the live segments running on from either of
+// its ends in the same direction, removing them.
+func (t *TileMap) join(s tileSegment) tileSegment {
+	d := s.direction()
+	for _, id := range t.endAt[s.a] {
+		if prev := t.segments[id]; prev.direction() == d {
+			t.removeSegment(id)
+			s.a = prev.a
+			s.faces = append(prev.faces, s.faces...)
+			break
+		}
+	}
+	for _, id := range t.startAt[s.b] {
+		if next := t.segments[id]; next.direction() == d {
+			t.removeSegment(id)
+			s.b = next.b
+			s.faces = append(s.faces, next.faces...)
+			break
+		}
+	}
+	return s
+}
+
+// direction returns the step from one face of the segment to the next.
+func (s tileSegment) direction() gridPoint {
+	n := len(s.faces)
+	return gridPoint{(s.b.X - s.a.X) / n, (s.b.Y - s.a.Y) / n}
+}
+
+// addSegment stores a segment, in the slot of a removed one if there is one.
+func (t *TileMap) addSegment(s tileSegment) {
+	id := len(t.segments)
+	if n := len(t.free); n > 0 {
+		id, t.free = t.free[n-1], t.free[:n-1]
+		t.segments[id] = s
+	} else {
+		t.segments = append(t.segments, s)
+	}
+	for _, k := range s.faces {
+		t.byFace[k] = id
+	}
+	t.startAt[s.a] = append(t.startAt[s.a], id)
+	t.endAt[s.b] = append(t.endAt[s.b], id)
+}
+
+func (t *TileMap) removeSegment(id int) {
+	s := &t.segments[id]
+	s.alive = false
+	for _, k := range s.faces {
+		delete(t.byFace, k)
+	}
+	t.startAt[s.a] = without(t.startAt[s.a], id)
+	t.endAt[s.b] = without(t.endAt[s.b], id)
+	s.faces = nil
+	t.free = append(t.free, id)
+}
+
+func without(ids []int, id int) []int {
+	out := ids[:0]
+	for _, i := range ids {
+		if i != id {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// outlineFace returns the directed edge of a tile face if it lies on the
+// outline, that is when the tile has the face and the neighbour across it
+// does not cover it.
+func (t *TileMap) outlineFace(k faceKey) (gridPoint, gridPoint, bool) {
+	x, y := k.X, k.Y
+	tile := t.Tile(x, y)
+	if !hasFace(tile, k.Face) {
+		return gridPoint{}, gridPoint{}, false
+	}
+	switch k.Face {
+	case top:
+		if coversFace(t.Tile(x, y-1), bottom) {
+			return gridPoint{}, gridPoint{}, false
+		}
+		return gridPoint{x, y}, gridPoint{x + 1, y}, true
+	case right:
+		if coversFace(t.Tile(x+1, y), left) {
+			return gridPoint{}, gridPoint{}, false
+		}
+		return gridPoint{x + 1, y}, gridPoint{x + 1, y + 1}, true
+	case bottom:
+		if coversFace(t.Tile(x, y+1), top) {
+			return gridPoint{}, gridPoint{}, false
+		}
+		return gridPoint{x + 1, y + 1}, gridPoint{x, y + 1}, true
+	case left:
+		if coversFace(t.Tile(x-1, y), right) {
+			return gridPoint{}, gridPoint{}, false
+		}
+		return gridPoint{x, y + 1}, gridPoint{x, y}, true
+	}
+	if tile == SlopeUp {
+		return gridPoint{x, y + 1}, gridPoint{x + 1, y}, true
+	}
+	return gridPoint{x, y}, gridPoint{x + 1, y + 1}, true
+}
+
+// hasFace reports whether a tile of the given kind has a solid face on side f.
+func hasFace(tile Tile, f face) bool {
+	switch tile {
+	case Solid:
+		return f != diagonal
+	case OneWay:
+		return f == top
+	case SlopeUp:
+		return f == right || f == bottom || f == diagonal
+	case SlopeDown:
+		return f == left || f == bottom || f == diagonal
+	}
+	return false
+}
+
+// coversFace reports whether a tile fills the whole of its side f, hiding
+// the face of the neighbour behind it. One-way platforms hide nothing.
+func coversFace(tile Tile, f face) bool {
+	return tile != OneWay && f != diagonal && hasFace(tile, f)
+}
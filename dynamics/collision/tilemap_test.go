@@ -0,0 +1,72 @@
+package collision
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// sortedEdges returns the edges of a tile map in a fixed order, so two maps
+// can be compared.
+func sortedEdges(t *TileMap) [][2]vector.Vector {
+	edges := t.Edges()
+	less := func(a, b vector.Vector) bool { return a.X < b.X || a.X == b.X && a.Y < b.Y }
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i][0] != edges[j][0] {
+			return less(edges[i][0], edges[j][0])
+		}
+		return less(edges[i][1], edges[j][1])
+	})
+	return edges
+}
+
+func TestSetTileMatchesRebuild(t *testing.T) {
+	const width, height = 12, 9
+	rng := rand.New(rand.NewSource(1))
+	tiles := make([][]Tile, height)
+	for y := range tiles {
+		tiles[y] = make([]Tile, width)
+	}
+	m := NewTileMap(vector.Vector{}, 16, tiles)
+	most := 0 // Most segments live at once
+	for i := 0; i < 2000; i++ {
+		x, y := rng.Intn(width), rng.Intn(height)
+		tile := Tile(rng.Intn(int(SlopeDown) + 1))
+		tiles[y][x] = tile
+		m.SetTile(x, y, tile)
+		most = max(most, len(m.Edges()))
+		if i%50 != 49 {
+			continue
+		}
+		got, want := sortedEdges(m), sortedEdges(NewTileMap(vector.Vector{}, 16, tiles))
+		if len(got) != len(want) {
+			t.Fatalf("after %d changes: %d edges, want %d", i+1, len(got), len(want))
+		}
+		for j := range got {
+			if got[j] != want[j] {
+				t.Fatalf("after %d changes: edge %d is %v, want %v", i+1, j, got[j], want[j])
+			}
+		}
+		// Every face must point at the live segment holding it
+		for k, id := range m.byFace {
+			s := m.segments[id]
+			if !s.alive {
+				t.Fatalf("after %d changes: face %v belongs to a removed segment", i+1, k)
+			}
+			found := false
+			for _, f := range s.faces {
+				found = found || f == k
+			}
+			if !found {
+				t.Fatalf("after %d changes: face %v is not in its segment", i+1, k)
+			}
+		}
+	}
+	// Freed slots are reused, so there are never more slots than there were
+	// live segments at once
+	if len(m.segments) > most {
+		t.Errorf("%d segment slots, but at most %d segments were live", len(m.segments), most)
+	}
+}
@@ -0,0 +1,203 @@
+package fracture
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/rudransh61/Physix-go/dynamics/collision"
+	"github.com/rudransh61/Physix-go/pkg/material"
+	"github.com/rudransh61/Physix-go/pkg/polygon"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Settings decide how a body breaks.
+type Settings struct {
+	Shards  int     // Shards a body breaks into
+	Spread  float64 // How far from the impact the shards reach, as a fraction of the body's size
+	MinArea float64 // Bodies smaller than this never break, so shards stop breaking at some size
+}
+
+// Fracturer breaks polygon and rectangle bodies into Voronoi shards when they
+// are hit harder than the Toughness of their material. Its Settings apply to
+// every material without settings of its own.
+type Fracturer struct {
+	Settings
+	materials map[*material.Material]Settings
+	rand      *rand.Rand
+}
+
+// New creates a fracturer with 8 shards per break. Breaks are placed with a
+// random source seeded with seed, so a replayed scene breaks the same way.
+func New(seed int64) *Fracturer {
+	return &Fracturer{
+		Settings: Settings{Shards: 8, Spread: 0.5},
+		rand:     rand.New(rand.NewSource(seed)),
+	}
+}
+
+// SetMaterial gives bodies of a material their own settings, such as glass
+// that shatters into many small shards next to stone that splits in two.
+func (f *Fracturer) SetMaterial(m *material.Material, s Settings) {
+	if f.materials == nil {
+		f.materials = make(map[*material.Material]Settings)
+	}
+	f.materials[m] = s
+}
+
+// RemoveMaterial drops the settings of a material, which goes back to the
+// fracturer's own.
+func (f *Fracturer) RemoveMaterial(m *material.Material) {
+	delete(f.materials, m)
+}
+
+// SettingsFor returns the settings a body breaks with.
+func (f *Fracturer) SettingsFor(rb *rigidbody.RigidBody) Settings {
+	if s, ok := f.materials[rb.Material]; ok && rb.Material != nil {
+		return s
+	}
+	return f.Settings
+}
+
+// Break is a body that broke and the shards that replace it. The methods of
+// a nil Break leave the world as it is, so the result of Polygon or Rectangle
+// can be applied whether the body broke or not.
+type Break struct {
+	Body   *rigidbody.RigidBody // The body that broke, to be removed
+	Shards []*polygon.Polygon
+}
+
+// Replace returns bodies without the broken body and with the shards' bodies
+// added.
+func (b *Break) Replace(bodies []*rigidbody.RigidBody) []*rigidbody.RigidBody {
+	if b == nil {
+		return bodies
+	}
+	kept := bodies[:0]
+	for _, rb := range bodies {
+		if rb != b.Body {
+			kept = append(kept, rb)
+		}
+	}
+	for _, shard := range b.Shards {
+		kept = append(kept, &shard.RigidBody)
+	}
+	return kept
+}
+
+// ReplacePolygon returns polygons without the broken one and with the shards
+// added.
+func (b *Break) ReplacePolygon(polygons []*polygon.Polygon) []*polygon.Polygon {
+	if b == nil {
+		return polygons
+	}
+	kept := polygons[:0]
+	for _, p := range polygons {
+		if &p.RigidBody != b.Body {
+			kept = append(kept, p)
+		}
+	}
+	return append(kept, b.Shards...)
+}
+
+// Impact returns where and how hard the bodies of a manifold hit, after it
+// has been resolved with collision.ApplyImpulses.
+func Impact(m *collision.Manifold) (vector.Vector, float64) {
+	var point vector.Vector
+	for _, c := range m.Contacts {
+		point = point.Add(c)
+	}
+	if len(m.Contacts) > 0 {
+		point = point.Scale(1 / float64(len(m.Contacts)))
+	}
+	return point, m.Impulse
+}
+
+// Breaks reports whether an impulse is enough to fracture the body. Bodies
+// whose material has no Toughness never break.
+func Breaks(rb *rigidbody.RigidBody, impulse float64) bool {
+	toughness := rb.Surface().Toughness
+	return toughness > 0 && impulse >= toughness
+}
+
+// Polygon breaks a polygon hit at impact with the given impulse. It returns
+// the shards that replace the polygon, or nil when the polygon holds.
+func (f *Fracturer) Polygon(p *polygon.Polygon, impact vector.Vector, impulse float64) *Break {
+	if !Breaks(&p.RigidBody, impulse) {
+		return nil
+	}
+	area := polygon.Area(p.Vertices)
+	if area == 0 {
+		return nil
+	}
+	return f.shatter(&p.RigidBody, p.Vertices, p.Mass/area, impact)
+}
+
+// Rectangle breaks a rectangle body hit at impact with the given impulse. It
+// returns the polygon shards that replace the rectangle, or nil when the
+// rectangle holds.
+func (f *Fracturer) Rectangle(rb *rigidbody.RigidBody, impact vector.Vector, impulse float64) *Break {
+	if rb.Shape != "Rectangle" || !Breaks(rb, impulse) {
+		return nil
+	}
+	area := rb.Width * rb.Height
+	if area == 0 {
+		return nil
+	}
+	hw, hh := rb.Width/2, rb.Height/2
+	outline := []vector.Vector{{X: -hw, Y: -hh}, {X: hw, Y: -hh}, {X: hw, Y: hh}, {X: -hw, Y: hh}}
+	return f.shatter(rb, outline, rb.Mass/area, impact)
+}
+
+// shatter splits a body-space outline into Voronoi cells around sites
+// scattered about the impact, and builds a polygon for each.
+func (f *Fracturer) shatter(rb *rigidbody.RigidBody, outline []vector.Vector, density float64, impact vector.Vector) *Break {
+	s := f.SettingsFor(rb)
+	area := polygon.Area(outline)
+	if area < s.MinArea || s.Shards < 2 {
+		return nil
+	}
+	sites := f.sites(s, outline, rb.LocalPoint(impact), math.Sqrt(area))
+	cells := polygon.Voronoi(outline, sites)
+	if len(cells) < 2 {
+		return nil
+	}
+	shards := make([]*polygon.Polygon, len(cells))
+	for i, cell := range cells {
+		shards[i] = polygon.NewPiece(rb, cell, density)
+	}
+	return &Break{Body: rb, Shards: shards}
+}
+
+// sites scatters the Voronoi sites inside the outline, denser near the
+// impact so the shards are finer where the body was hit.
+func (f *Fracturer) sites(s Settings, outline []vector.Vector, impact vector.Vector, size float64) []vector.Vector {
+	if f.rand == nil {
+		f.rand = rand.New(rand.NewSource(0))
+	}
+	reach := s.Spread * size
+	if reach <= 0 {
+		reach = size
+	}
+	sites := make([]vector.Vector, 0, s.Shards)
+	for len(sites) < s.Shards {
+		placed := false
+		for try := 0; try < 20 && !placed; try++ {
+			// Spreading the radius evenly crowds the sites near the impact
+			angle := f.rand.Float64() * 2 * math.Pi
+			r := f.rand.Float64() * reach
+			site := impact.Add(vector.Vector{X: r * math.Cos(angle), Y: r * math.Sin(angle)})
+			if polygon.Contains(outline, site) {
+				sites = append(sites, site)
+				placed = true
+			}
+		}
+		if !placed {
+			reach *= 2 // The impact is far from the bulk of the body
+		}
+		if reach > 100*size {
+			break
+		}
+	}
+	return sites
+}
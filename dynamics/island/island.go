@@ -0,0 +1,221 @@
+package island
+
+import (
+	"math"
+	"sort"
+
+	"github.com/rudransh61/Physix-go/dynamics/collision"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/spring"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+var (
+	LinearSleepTolerance  float64 = 0.5               // Speed below which a body may fall asleep
+	AngularSleepTolerance float64 = 2 * math.Pi / 180 // Angular speed below which a body may fall asleep
+	TimeToSleep           float64 = 0.5               // Time a whole island must stay slow before it sleeps
+	ContactMargin         float64 = 0.5               // Gap under which two bodies count as touching
+)
+
+// Link ties two bodies into the same island. It stands for a contact, a joint or a spring.
+type Link struct {
+	A, B *rigidbody.RigidBody
+}
+
+// Island is a group of dynamic bodies connected through links.
+// Its bodies fall asleep and wake up together.
+type Island struct {
+	Bodies []*rigidbody.RigidBody
+}
+
+// SpringLinks returns a link for every spring.
+func SpringLinks(springs []*spring.Spring) []Link {
+	links := make([]Link, 0, len(springs))
+	for _, s := range springs {
+		links = append(links, Link{A: s.BallA, B: s.BallB})
+	}
+	return links
+}
+
+// FindContacts returns a link for every pair of bodies whose bounding boxes
+// are within ContactMargin of each other. Pairs of bodies that are both at rest
+// are included too, so a settled stack stays a single island. The boxes are
+// swept from left to right, so only bodies that overlap along x are compared.
+func FindContacts(bodies []*rigidbody.RigidBody) []Link {
+	var links []Link
+	bounds := make([]collision.AABB, len(bodies))
+	order := make([]int, len(bodies))
+	for i, b := range bodies {
+		bounds[i] = collision.BoundsOf(b).Expand(ContactMargin)
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return bounds[order[a]].Min.X < bounds[order[b]].Min.X
+	})
+	var open []int // Bodies whose boxes reach the left edge of the next one
+	for _, i := range order {
+		kept := open[:0]
+		for _, j := range open {
+			if bounds[j].Max.X >= bounds[i].Min.X {
+				kept = append(kept, j)
+			}
+		}
+		open = kept
+		for _, j := range open {
+			if !bodies[i].IsDynamic() && !bodies[j].IsDynamic() {
+				continue
+			}
+			if bounds[i].Overlaps(bounds[j]) {
+				links = append(links, Link{A: bodies[j], B: bodies[i]})
+			}
+		}
+		open = append(open, i)
+	}
+	return links
+}
+
+// Build groups the dynamic bodies into islands. Static and kinematic bodies
+// never join an island, so two piles resting on the same ground stay separate.
+func Build(bodies []*rigidbody.RigidBody, links []Link) []Island {
+	index := make(map[*rigidbody.RigidBody]int, len(bodies))
+	var movable []*rigidbody.RigidBody
+	for _, b := range bodies {
+		if !b.IsDynamic() {
+			continue
+		}
+		if _, ok := index[b]; ok {
+			continue
+		}
+		index[b] = len(movable)
+		movable = append(movable, b)
+	}
+	parent := make([]int, len(movable))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+
+	for _, l := range links {
+		a, okA := index[l.A]
+		b, okB := index[l.B]
+		if !okA || !okB {
+			continue
+		}
+		if ra, rb := find(a), find(b); ra != rb {
+			parent[ra] = rb
+		}
+	}
+
+	groups := make(map[int]int)
+	var islands []Island
+	for i, b := range movable {
+		root := find(i)
+		g, ok := groups[root]
+		if !ok {
+			g = len(islands)
+			groups[root] = g
+			islands = append(islands, Island{})
+		}
+		islands[g].Bodies = append(islands[g].Bodies, b)
+	}
+	return islands
+}
+
+// Update advances the sleep timers of every island by dt.
+// An island falls asleep once all its bodies have stayed below the sleep
+// tolerances for TimeToSleep. An island that holds both sleeping and awake
+// bodies has just gained a contact or link, and is woken up as a whole.
+func Update(islands []Island, dt float64) {
+	for _, isl := range islands {
+		isl.Update(dt)
+	}
+}
+
+// Update advances the sleep timers of the island's bodies by dt.
+func (isl Island) Update(dt float64) {
+	awake := false
+	asleep := false
+	minSleepTime := math.MaxFloat64
+	for _, b := range isl.Bodies {
+		if b.IsSleeping {
+			asleep = true
+			continue
+		}
+		awake = true
+		if b.Velocity.Magnitude() > LinearSleepTolerance || math.Abs(b.AngularVelocity) > AngularSleepTolerance {
+			b.SleepTime = 0
+		} else {
+			b.SleepTime += dt
+		}
+		minSleepTime = math.Min(minSleepTime, b.SleepTime)
+	}
+
+	if !awake {
+		return
+	}
+	if asleep {
+		isl.WakeUp()
+		return
+	}
+	if minSleepTime >= TimeToSleep {
+		isl.Sleep()
+	}
+}
+
+// Sleep puts every body of the island to sleep.
+func (isl Island) Sleep() {
+	for _, b := range isl.Bodies {
+		b.Sleep()
+	}
+}
+
+// WakeUp wakes every body of the island.
+func (isl Island) WakeUp() {
+	for _, b := range isl.Bodies {
+		b.WakeUp()
+	}
+}
+
+// IsSleeping reports whether every body of the island is asleep.
+func (isl Island) IsSleeping() bool {
+	for _, b := range isl.Bodies {
+		if !b.IsSleeping {
+			return false
+		}
+	}
+	return true
+}
+
+// Step finds contacts between the bodies, builds islands from the contacts
+// and the extra links (springs, joints), and updates their sleep state.
+func Step(bodies []*rigidbody.RigidBody, links []Link, dt float64) []Island {
+	all := append(FindContacts(bodies), links...)
+	islands := Build(bodies, all)
+	Update(islands, dt)
+	wakeKinematicContacts(all)
+	return islands
+}
+
+// wakeKinematicContacts keeps bodies touching a moving kinematic body awake,
+// so a crate riding a lift never falls asleep on it.
+func wakeKinematicContacts(links []Link) {
+	for _, l := range links {
+		if moving(l.A) && l.B.IsDynamic() {
+			l.B.WakeUp()
+		}
+		if moving(l.B) && l.A.IsDynamic() {
+			l.A.WakeUp()
+		}
+	}
+}
+
+func moving(rb *rigidbody.RigidBody) bool {
+	return rb.IsKinematic() && (rb.Velocity != vector.Vector{} || rb.AngularVelocity != 0)
+}
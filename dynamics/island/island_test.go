@@ -0,0 +1,52 @@
+package island
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/rudransh61/Physix-go/dynamics/collision"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+func TestFindContactsMatchesEveryPair(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var bodies []*rigidbody.RigidBody
+	for i := 0; i < 200; i++ {
+		b := &rigidbody.RigidBody{
+			Position: vector.Vector{X: rng.Float64() * 400, Y: rng.Float64() * 400},
+			Shape:    "Circle",
+			Radius:   2 + rng.Float64()*10,
+			Type:     rigidbody.Dynamic,
+		}
+		if i%10 == 0 {
+			b.Type = rigidbody.Static
+		}
+		bodies = append(bodies, b)
+	}
+	found := make(map[Link]bool)
+	for _, l := range FindContacts(bodies) {
+		if found[l] || found[Link{A: l.B, B: l.A}] {
+			t.Fatalf("bodies at %v and %v linked twice", l.A.Position, l.B.Position)
+		}
+		found[l] = true
+	}
+	want := 0
+	for i, a := range bodies {
+		for _, b := range bodies[i+1:] {
+			if !a.IsDynamic() && !b.IsDynamic() {
+				continue
+			}
+			if !collision.BoundsOf(a).Expand(ContactMargin).Overlaps(collision.BoundsOf(b).Expand(ContactMargin)) {
+				continue
+			}
+			want++
+			if !found[Link{A: a, B: b}] && !found[Link{A: b, B: a}] {
+				t.Errorf("bodies at %v and %v not linked", a.Position, b.Position)
+			}
+		}
+	}
+	if len(found) != want {
+		t.Errorf("got %d links, want %d", len(found), want)
+	}
+}
@@ -5,84 +5,61 @@ import (
 	"github.com/rudransh61/Physix-go/pkg/polygon"
 	"github.com/rudransh61/Physix-go/pkg/rigidbody"
 	"github.com/rudransh61/Physix-go/pkg/vector"
-	"math"
 )
 
-// ApplyForcePolygon applies force to a polygon and rotates every vertex about the centroid.
+// ApplyForcePolygon applies force to a polygon. The polygon moves and turns
+// like any other body; its vertices follow its Position and Angle.
 func ApplyForcePolygon(pg *polygon.Polygon, force vector.Vector, dt float64) {
-    if pg.IsMovable {
-        // Use Newton's second law: F = ma -> a = F/m
-        pg.Force = force
-        acceleration := vector.Vector{
-            X: pg.Force.X / pg.Mass,
-            Y: pg.Force.Y / pg.Mass,
-        }
-
-        // Update velocity using acceleration and time step
-        pg.Velocity.X += acceleration.X * dt
-        pg.Velocity.Y += acceleration.Y * dt
-
-        // Update position using velocity and time step for each vertex
-        for i := range pg.Vertices {
-            pg.Vertices[i].X += pg.Velocity.X * dt
-            pg.Vertices[i].Y += pg.Velocity.Y * dt
-        }
-
-        // Calculate centroid
-        centroid := polygon.CalculateCentroid(pg.Vertices)
-
-        // Rotate each vertex about the centroid
-        for i := range pg.Vertices {
-            // Translate vertex to the origin
-            translatedX := pg.Vertices[i].X - centroid.X
-            translatedY := pg.Vertices[i].Y - centroid.Y
-
-            // Rotate the vertex
-            rotatedX := translatedX*math.Cos(pg.Rotation) - translatedY*math.Sin(pg.Rotation)
-            rotatedY := translatedX*math.Sin(pg.Rotation) + translatedY*math.Cos(pg.Rotation)
-
-            // Translate the vertex back to its original position
-            pg.Vertices[i].X = rotatedX + centroid.X
-            pg.Vertices[i].Y = rotatedY + centroid.Y
-        }
-
-        pg.UpdatePosition()
-    }
+	ApplyForce(&pg.RigidBody, force, dt)
 }
 
-
 // ApplyForce applies a force to a rigid body.
 func ApplyForce(rb *rigidbody.RigidBody, force vector.Vector, dt float64) {
-	if rb.IsMovable {
-		// Use Newton's second law: F = ma -> a = F/m
+	if rb.IsKinematic() {
+		// Kinematic bodies follow their velocity and ignore the force
+		rb.Position = rb.Position.Add(rb.Velocity.Scale(dt))
+		rb.Angle += rb.AngularVelocity * dt
+		return
+	}
+	if !wakeForForce(rb, force) {
+		return
+	}
+	if rb.IsDynamic() {
+		// Use Newton's second law: F = ma -> a = F * (1/m)
 		// rb.Force = rb.Force.Add(force)
 
 		rb.Force = force
-		acceleration := rb.Force.Scale(1 / rb.Mass)
+		acceleration := rb.Force.Scale(rb.InverseMass())
 
 		// Update velocity using acceleration and time step
 		rb.Velocity = rb.Velocity.Add(acceleration.Scale(dt))
 
 		// Update position using velocity and time step
 		rb.Position = rb.Position.Add(rb.Velocity.Scale(dt))
+
+		UpdateRotation(rb, dt)
 	}
 }
 
-// UpdateRotation updates the rotation of the rigid body based on its angular velocity.
-func UpdateRotation(rb *rigidbody.RigidBody, dt float64) {
-	// I := 1.0 // Define MOI
-	// // Update Angular velocity
-	// if rb.Shape == "Circle" {
-	// 	I = 0.5 * rb.Mass * rb.Radius * rb.Radius // Moment of Inertia of Circular Disk
-	// } else if rb.Shape == "Rectangle" {
-	// 	I = 0.16668 * rb.Mass * rb.Width * rb.Height // // Moment of Inertia of Plank defined by us.
-	// 	// 1/24 *b*l*m
-	// }
-	// rb.AngularAcceleration = rb.Torque / I
-	// rb.AngularVelocity += rb.AngularAcceleration*dt
-	// // Update rotation based on angular velocity
-	// angle := rb.AngularVelocity * dt
-	// rb.Position.X = math.Cos(angle)*rb.Position.X - math.Sin(angle)*rb.Position.Y
-	// rb.Position.Y = math.Sin(angle)*rb.Position.X + math.Cos(angle)*rb.Position.Y
+// wakeForForce reports whether a body should be integrated under force.
+// A sleeping body ignores the force it fell asleep under (a constant gravity,
+// say) and is woken up by any different force.
+func wakeForForce(rb *rigidbody.RigidBody, force vector.Vector) bool {
+	if !rb.IsSleeping {
+		return true
+	}
+	if force == rb.Force {
+		return false
+	}
+	rb.WakeUp()
+	return true
+}
 
-}
\ No newline at end of file
+// UpdateRotation updates the rotation of the rigid body from the torque applied
+// with ApplyTorque since the last step, then clears that torque.
+func UpdateRotation(rb *rigidbody.RigidBody, dt float64) {
+	rb.AngularAcceleration = rb.Torque * rb.InverseInertia()
+	rb.AngularVelocity += rb.AngularAcceleration * dt
+	rb.Angle += rb.AngularVelocity * dt
+	rb.Torque = 0
+}
@@ -0,0 +1,63 @@
+package sph
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// The smoothing kernels of Müller et al., "Particle-Based Fluid Simulation
+// for Interactive Applications", scaled to integrate to 1 in two dimensions.
+// r is the offset between two particles and h the smoothing radius; every
+// kernel is 0 from h on.
+type kernels struct {
+	h, h2                      float64
+	poly6, poly6Grad, poly6Lap float64 // Constants of the poly6 kernel and its derivatives
+	spikyGrad, viscosityLap    float64
+}
+
+func newKernels(h float64) kernels {
+	h5 := math.Pow(h, 5)
+	h8 := math.Pow(h, 8)
+	return kernels{
+		h:            h,
+		h2:           h * h,
+		poly6:        4 / (math.Pi * h8),
+		poly6Grad:    -24 / (math.Pi * h8),
+		poly6Lap:     -48 / (math.Pi * h8),
+		spikyGrad:    -30 / (math.Pi * h5),
+		viscosityLap: 40 / (math.Pi * h5),
+	}
+}
+
+// density is the poly6 kernel, used to sum up density, at a squared distance.
+func (k kernels) density(r2 float64) float64 {
+	d := k.h2 - r2
+	return k.poly6 * d * d * d
+}
+
+// colorGradient is the gradient of the poly6 kernel, used for the surface
+// normal.
+func (k kernels) colorGradient(r vector.Vector, r2 float64) vector.Vector {
+	d := k.h2 - r2
+	return r.Scale(k.poly6Grad * d * d)
+}
+
+// colorLaplacian is the Laplacian of the poly6 kernel, used for the
+// curvature of the surface.
+func (k kernels) colorLaplacian(r2 float64) float64 {
+	return k.poly6Lap * (k.h2 - r2) * (k.h2 - 3*r2)
+}
+
+// pressureGradient is the gradient of the spiky kernel, which does not
+// flatten out as particles close in, so pressure keeps them apart.
+func (k kernels) pressureGradient(r vector.Vector, distance float64) vector.Vector {
+	d := k.h - distance
+	return r.Scale(k.spikyGrad * d * d / distance)
+}
+
+// viscosity is the Laplacian of the viscosity kernel, which is never
+// negative, so viscosity only ever slows particles relative to each other.
+func (k kernels) viscosity(distance float64) float64 {
+	return k.viscosityLap * (k.h - distance)
+}
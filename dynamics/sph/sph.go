@@ -0,0 +1,263 @@
+package sph
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/dynamics/collision"
+	"github.com/rudransh61/Physix-go/pkg/material"
+	"github.com/rudransh61/Physix-go/pkg/polygon"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Particle is a small parcel of fluid.
+type Particle struct {
+	Position vector.Vector
+	Velocity vector.Vector
+	Density  float64 // Density where the particle is, summed from its neighbours
+	Pressure float64
+
+	acceleration vector.Vector
+}
+
+// Equation is the equation of state that turns density into pressure.
+type Equation int
+
+const (
+	// Tait is the weakly compressible equation of state for liquids,
+	// Stiffness·((ρ/ρ0)^Gamma - 1). Pressure below rest density is
+	// dropped, so the liquid does not pull itself into clumps.
+	Tait Equation = iota
+	// IdealGas is the equation of state for gases, Stiffness·(ρ - ρ0).
+	// Pressure goes negative below rest density, so the fluid also pulls
+	// together.
+	IdealGas
+)
+
+// Fluid is a fluid made of particles moved by smoothed particle
+// hydrodynamics (SPH). Each particle's density is summed from the particles
+// within Smoothing of it, which gives its pressure; pressure, viscosity and
+// surface tension between neighbours then move it. Neighbours are found with
+// a grid of cells Smoothing wide.
+//
+// The particles collide with Bodies and Polygons as circles of
+// ParticleRadius, through the collision package. Static bodies are walls and
+// containers; dynamic bodies are pushed by the fluid as it is pushed by them,
+// so boxes float and splash.
+type Fluid struct {
+	Particles []*Particle
+	Bodies    []*rigidbody.RigidBody // Rectangles, circles, capsules, segments and bodies made of fixtures
+	Polygons  []*polygon.Polygon     // Convex polygons; decompose concave ones into compounds and add them to Bodies
+
+	Gravity        vector.Vector // Acceleration of every particle, such as {0, 98}
+	Smoothing      float64       // How far a particle feels its neighbours
+	ParticleRadius float64       // Radius the particles collide with bodies at
+	Mass           float64       // Mass of every particle
+	RestDensity    float64       // Density the fluid settles at
+	Equation       Equation
+	Stiffness      float64 // How hard the fluid resists compression; deeper fluid needs more
+	Gamma          float64 // Exponent of the Tait equation, 7 for water
+	Viscosity      float64 // How thick the fluid is; too little lets it jitter and never settle
+	SurfaceTension float64 // How hard the surface pulls itself flat, 0 for none
+	Substeps       int     // Substeps per Step, 1 if 0
+
+	Materials *material.Table // Friction and restitution of chosen material pairs against the bodies, nil for none
+
+	kernels kernels
+	grid    map[cell][]int
+	probe   rigidbody.RigidBody // Stands in for a particle against the bodies
+}
+
+type cell struct{ X, Y int }
+
+// NewFluid creates a fluid with a smoothing radius, filled in with settings
+// for water in a scene measured in pixels: particles are spaced half the
+// smoothing radius apart at a rest density of 1, and the fluid follows the
+// Tait equation. Add particles with Add or Fill.
+func NewFluid(smoothing float64, gravity vector.Vector) *Fluid {
+	spacing := smoothing / 2
+	return &Fluid{
+		Gravity:        gravity,
+		Smoothing:      smoothing,
+		ParticleRadius: spacing / 2,
+		Mass:           spacing * spacing,
+		RestDensity:    1,
+		Equation:       Tait,
+		Stiffness:      20000,
+		Gamma:          7,
+		Viscosity:      100,
+		Substeps:       4,
+	}
+}
+
+// Add adds a particle at rest at position.
+func (f *Fluid) Add(position vector.Vector) *Particle {
+	p := &Particle{Position: position}
+	f.Particles = append(f.Particles, p)
+	return p
+}
+
+// Fill fills the rectangle from min to max with particles at rest, spaced
+// half the smoothing radius apart, and returns them.
+func (f *Fluid) Fill(min, max vector.Vector) []*Particle {
+	spacing := f.Smoothing / 2
+	start := len(f.Particles)
+	for y := min.Y + spacing/2; y < max.Y; y += spacing {
+		for x := min.X + spacing/2; x < max.X; x += spacing {
+			f.Add(vector.Vector{X: x, Y: y})
+		}
+	}
+	return f.Particles[start:]
+}
+
+// Step advances the fluid by dt, in Substeps. It does nothing without a
+// positive Smoothing and Mass.
+func (f *Fluid) Step(dt float64) {
+	if dt <= 0 || f.Smoothing <= 0 || f.Mass <= 0 {
+		return
+	}
+	substeps := f.Substeps
+	if substeps <= 0 {
+		substeps = 1
+	}
+	f.kernels = newKernels(f.Smoothing)
+	h := dt / float64(substeps)
+	for step := 0; step < substeps; step++ {
+		f.buildGrid()
+		f.computeDensity()
+		f.computeForces()
+		for _, p := range f.Particles {
+			p.Velocity = p.Velocity.Add(p.acceleration.Scale(h))
+			p.Position = p.Position.Add(p.Velocity.Scale(h))
+		}
+		f.collide()
+	}
+}
+
+func (f *Fluid) cellOf(v vector.Vector) cell {
+	return cell{int(math.Floor(v.X / f.Smoothing)), int(math.Floor(v.Y / f.Smoothing))}
+}
+
+// buildGrid sorts the particles into cells as wide as the smoothing radius,
+// so all the neighbours of a particle are in the cells around its own.
+func (f *Fluid) buildGrid() {
+	if f.grid == nil {
+		f.grid = make(map[cell][]int)
+	}
+	for k, indices := range f.grid {
+		if len(indices) == 0 {
+			delete(f.grid, k) // Empty since the last substep, so the fluid has left it
+		} else {
+			f.grid[k] = indices[:0]
+		}
+	}
+	for i, p := range f.Particles {
+		c := f.cellOf(p.Position)
+		f.grid[c] = append(f.grid[c], i)
+	}
+}
+
+// neighbours calls visit for every particle within the smoothing radius of p,
+// p included, with the offset from it to p and their squared distance.
+func (f *Fluid) neighbours(p *Particle, visit func(q *Particle, r vector.Vector, r2 float64)) {
+	c := f.cellOf(p.Position)
+	for x := c.X - 1; x <= c.X+1; x++ {
+		for y := c.Y - 1; y <= c.Y+1; y++ {
+			for _, j := range f.grid[cell{x, y}] {
+				q := f.Particles[j]
+				r := p.Position.Sub(q.Position)
+				if r2 := r.InnerProduct(r); r2 < f.kernels.h2 {
+					visit(q, r, r2)
+				}
+			}
+		}
+	}
+}
+
+// computeDensity sums up the density of every particle and works out its
+// pressure.
+func (f *Fluid) computeDensity() {
+	for _, p := range f.Particles {
+		density := 0.0
+		f.neighbours(p, func(q *Particle, r vector.Vector, r2 float64) {
+			density += f.Mass * f.kernels.density(r2)
+		})
+		p.Density = density
+		p.Pressure = f.pressure(density)
+	}
+}
+
+// pressure returns the pressure of the fluid at a density.
+func (f *Fluid) pressure(density float64) float64 {
+	if f.Equation == IdealGas {
+		return f.Stiffness * (density - f.RestDensity)
+	}
+	return f.Stiffness * math.Max(math.Pow(density/f.RestDensity, f.Gamma)-1, 0)
+}
+
+// computeForces works out the acceleration of every particle from pressure,
+// viscosity, surface tension and gravity.
+func (f *Fluid) computeForces() {
+	k := f.kernels
+	for _, p := range f.Particles {
+		var pressure, viscosity, normal vector.Vector
+		curvature := 0.0
+		f.neighbours(p, func(q *Particle, r vector.Vector, r2 float64) {
+			if q.Density == 0 {
+				return
+			}
+			volume := f.Mass / q.Density
+			normal = normal.Add(k.colorGradient(r, r2).Scale(volume))
+			curvature += k.colorLaplacian(r2) * volume
+			if q == p || r2 == 0 {
+				return
+			}
+			distance := math.Sqrt(r2)
+			shared := (p.Pressure + q.Pressure) / 2
+			pressure = pressure.Sub(k.pressureGradient(r, distance).Scale(volume * shared))
+			viscosity = viscosity.Add(q.Velocity.Sub(p.Velocity).Scale(volume * k.viscosity(distance)))
+		})
+		force := pressure.Add(viscosity.Scale(f.Viscosity))
+		// Only particles near the surface have a normal worth following
+		if length := normal.Magnitude(); f.SurfaceTension > 0 && length > 1/f.Smoothing {
+			force = force.Sub(normal.Scale(f.SurfaceTension * curvature / length))
+		}
+		p.acceleration = f.Gravity
+		if p.Density > 0 {
+			p.acceleration = p.acceleration.Add(force.Scale(1 / p.Density))
+		}
+	}
+}
+
+// collide resolves every particle against the bodies and polygons as a small
+// circle of the particle's mass, so both feel the impulse.
+func (f *Fluid) collide() {
+	if len(f.Bodies) == 0 && len(f.Polygons) == 0 {
+		return
+	}
+	f.probe = rigidbody.RigidBody{
+		Shape:  "Circle",
+		Radius: f.ParticleRadius,
+		Type:   rigidbody.Dynamic,
+	}
+	f.probe.SetMass(f.Mass)
+	// Particles slide, they do not roll
+	f.probe.Inertia, f.probe.InvInertia = math.Inf(1), 0
+	resolver := collision.Resolver{Materials: f.Materials}
+	for _, p := range f.Particles {
+		f.probe.Position, f.probe.Velocity = p.Position, p.Velocity
+		for _, body := range f.Bodies {
+			resolver.Resolve(&f.probe, body)
+		}
+		for _, poly := range f.Polygons {
+			m, ok := collision.CollidePolygon(poly, &f.probe)
+			if !ok {
+				continue
+			}
+			poly.WakeUp()
+			resolver.ApplyImpulses(m)
+			collision.Separate(m, collision.CorrectionPercent, collision.PenetrationSlop)
+		}
+		p.Position, p.Velocity = f.probe.Position, f.probe.Velocity
+	}
+}
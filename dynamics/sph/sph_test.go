@@ -0,0 +1,138 @@
+package sph
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+func wall(x, y, width, height float64) *rigidbody.RigidBody {
+	return &rigidbody.RigidBody{
+		Position: vector.Vector{X: x, Y: y},
+		Shape:    "Rectangle",
+		Width:    width,
+		Height:   height,
+		Type:     rigidbody.Static,
+	}
+}
+
+// tank returns a fluid in an open box 160 wide with its floor at y = 0.
+func tank() *Fluid {
+	f := NewFluid(16, vector.Vector{X: 0, Y: 98})
+	f.Bodies = []*rigidbody.RigidBody{
+		wall(80, 10, 200, 20),    // Floor
+		wall(-10, -100, 20, 220), // Left
+		wall(170, -100, 20, 220), // Right
+	}
+	return f
+}
+
+func TestFluidSettlesAtRestDensity(t *testing.T) {
+	f := tank()
+	f.Fill(vector.Vector{X: 0, Y: -80}, vector.Vector{X: 160, Y: 0})
+	for i := 0; i < 900; i++ {
+		f.Step(1.0 / 60)
+	}
+	// Particles near the surface have fewer neighbours, so only the deeper
+	// half is checked. The fluid is weakly compressible and squeezes a little
+	// under its own weight.
+	depths := make([]float64, len(f.Particles))
+	for i, p := range f.Particles {
+		depths[i] = p.Position.Y
+	}
+	sort.Float64s(depths)
+	half := depths[len(depths)/2]
+	density, n := 0.0, 0
+	for _, p := range f.Particles {
+		if p.Position.Y >= half {
+			density += p.Density
+			n++
+		}
+	}
+	density /= float64(n)
+	if math.Abs(density-f.RestDensity) > 0.1*f.RestDensity {
+		t.Errorf("mean density of the deeper half = %v, want about %v", density, f.RestDensity)
+	}
+	speed := 0.0
+	for _, p := range f.Particles {
+		speed += p.Velocity.Magnitude()
+	}
+	if speed /= float64(len(f.Particles)); speed > 1 {
+		t.Errorf("particles still move at %v on average", speed)
+	}
+}
+
+func TestBoxFloats(t *testing.T) {
+	f := tank()
+	f.Fill(vector.Vector{X: 0, Y: -80}, vector.Vector{X: 160, Y: 0})
+	box := &rigidbody.RigidBody{
+		Position: vector.Vector{X: 80, Y: -120},
+		Shape:    "Rectangle",
+		Width:    40,
+		Height:   20,
+		Type:     rigidbody.Dynamic,
+	}
+	box.SetDensity(0.5 * f.RestDensity)
+	f.Bodies = append(f.Bodies, box)
+	// The fluid pushes the box; moving it is left to the caller
+	for i := 0; i < 600; i++ {
+		f.Step(1.0 / 60)
+		box.Velocity = box.Velocity.Add(f.Gravity.Scale(1.0 / 60))
+		box.Position = box.Position.Add(box.Velocity.Scale(1.0 / 60))
+	}
+	// Half as dense as the fluid, the box floats half under its surface at
+	// about y = -80
+	if box.Position.Y < -100 || box.Position.Y > -60 {
+		t.Errorf("box is at %v, want it floating near -80", box.Position.Y)
+	}
+}
+
+func TestMomentumIsConserved(t *testing.T) {
+	f := NewFluid(16, vector.Vector{})
+	f.Fill(vector.Vector{X: 0, Y: 0}, vector.Vector{X: 64, Y: 64})
+	for _, p := range f.Particles {
+		p.Velocity = vector.Vector{X: 40, Y: 0}
+	}
+	box := &rigidbody.RigidBody{
+		Position: vector.Vector{X: 100, Y: 32},
+		Shape:    "Rectangle",
+		Width:    20,
+		Height:   40,
+		Type:     rigidbody.Dynamic,
+	}
+	box.SetDensity(1)
+	f.Bodies = append(f.Bodies, box)
+	momentum := func() vector.Vector {
+		total := box.Velocity.Scale(box.Mass)
+		for _, p := range f.Particles {
+			total = total.Add(p.Velocity.Scale(f.Mass))
+		}
+		return total
+	}
+	before := momentum()
+	for i := 0; i < 120; i++ {
+		f.Step(1.0 / 60)
+		box.Position = box.Position.Add(box.Velocity.Scale(1.0 / 60))
+	}
+	if box.Velocity.X <= 0 {
+		t.Fatalf("the fluid never pushed the box")
+	}
+	if after := momentum(); vector.Distance(before, after) > 1e-6*before.Magnitude() {
+		t.Errorf("momentum = %v, want %v", after, before)
+	}
+}
+
+func TestProbeSlides(t *testing.T) {
+	f := tank()
+	f.Add(vector.Vector{X: 80, Y: -1})
+	f.Step(1.0 / 60)
+	if f.probe.InverseMass() != 1/f.Mass {
+		t.Errorf("probe inverse mass = %v, want %v", f.probe.InverseMass(), 1/f.Mass)
+	}
+	if f.probe.InverseInertia() != 0 {
+		t.Errorf("probe inverse inertia = %v, want 0", f.probe.InverseInertia())
+	}
+}
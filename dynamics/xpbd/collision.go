@@ -0,0 +1,95 @@
+package xpbd
+
+import (
+	"github.com/rudransh61/Physix-go/dynamics/collision"
+	"github.com/rudransh61/Physix-go/pkg/material"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Collision is a contact constraint between a particle A and a body B, or two
+// particles. It pushes them apart as firmly as its compliance allows, turning
+// a dynamic body other than a circle about its centre, and holds them against
+// sliding with the friction of their materials. The solver makes one for every contact with
+// its Colliders, and between particles with SelfCollide, in every substep.
+type Collision struct {
+	A, B       *rigidbody.RigidBody
+	Compliance float64
+	Materials  *material.Table // Overrides for the friction of chosen material pairs, nil for none
+
+	startA, startB vector.Vector // Positions at the start of the substep, for friction
+	startAngleB    float64
+}
+
+// Project implements Constraint.
+func (c *Collision) Project(dt float64) {
+	m, ok := collision.Collide(c.A, c.B)
+	if !ok || m.Depth <= 0 {
+		return
+	}
+	// The normal points from A to B
+	n := m.Normal
+	contact := c.A.Position.Add(n.Scale(c.A.Radius))
+	if len(m.Contacts) > 0 {
+		contact = vector.Vector{}
+		for _, p := range m.Contacts {
+			contact = contact.Add(p)
+		}
+		contact = contact.Scale(1 / float64(len(m.Contacts)))
+	}
+	ra, rb := contact.Sub(c.A.Position), contact.Sub(c.B.Position)
+	w := weight(c.A, ra, n) + weight(c.B, rb, n)
+	alpha := c.Compliance / (dt * dt)
+	if w+alpha == 0 {
+		return
+	}
+	lambda := m.Depth / (w + alpha)
+	push(c.A, ra, n.Scale(-lambda))
+	push(c.B, rb, n.Scale(lambda))
+
+	// Friction works on how far the contact points slid past each other
+	// during the substep
+	ra, rb = contact.Sub(c.A.Position), contact.Sub(c.B.Position)
+	movedB := c.B.Position.Sub(c.startB).Add(rb.Sub(rb.Rotate(c.startAngleB - c.B.Angle)))
+	moved := c.A.Position.Sub(c.startA).Sub(movedB)
+	slide := moved.Sub(n.Scale(moved.InnerProduct(n)))
+	length := slide.Magnitude()
+	if length == 0 {
+		return
+	}
+	t := slide.Scale(1 / length)
+	wt := weight(c.A, ra, t) + weight(c.B, rb, t)
+	if wt == 0 {
+		return
+	}
+	held := length / wt
+	mix := collision.Resolver{Materials: c.Materials}.Mix(c.A, c.B)
+	if held >= mix.StaticFriction*lambda {
+		held = min(held, mix.DynamicFriction*lambda)
+	}
+	push(c.A, ra, t.Scale(-held))
+	push(c.B, rb, t.Scale(held))
+}
+
+// weight returns how easily a body gives way to a push along n at r from its
+// centre.
+func weight(rb *rigidbody.RigidBody, r, n vector.Vector) float64 {
+	rn := r.Cross(n)
+	return rb.InverseMass() + turning(rb)*rn*rn
+}
+
+// push moves a body by a positional impulse applied at r from its centre.
+func push(rb *rigidbody.RigidBody, r, impulse vector.Vector) {
+	rb.Position = rb.Position.Add(impulse.Scale(rb.InverseMass()))
+	rb.Angle += r.Cross(impulse) * turning(rb)
+}
+
+// turning returns how easily contacts turn a body. Circles, the particles
+// among them, are not turned: friction has to stop them sliding rather than
+// set them rolling, or cloth and rope would slip off everything.
+func turning(rb *rigidbody.RigidBody) float64 {
+	if rb.Shape == "Circle" {
+		return 0
+	}
+	return rb.InverseInertia()
+}
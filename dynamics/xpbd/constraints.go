@@ -0,0 +1,174 @@
+package xpbd
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/pkg/polygon"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Constraint is a condition on particle positions that the solver keeps, as
+// firmly as its compliance allows. A compliance of 0 makes it rigid; larger
+// values, the inverse of a stiffness, let it stretch like a spring.
+type Constraint interface {
+	// Project moves the particles towards satisfying the constraint over a
+	// substep of dt.
+	Project(dt float64)
+}
+
+// Distance keeps two particles Rest apart, like a rod or a rope link.
+type Distance struct {
+	A, B       *rigidbody.RigidBody
+	Rest       float64
+	Compliance float64
+}
+
+// NewDistance creates a distance constraint at the particles' current
+// distance.
+func NewDistance(a, b *rigidbody.RigidBody, compliance float64) *Distance {
+	return &Distance{A: a, B: b, Rest: vector.Distance(a.Position, b.Position), Compliance: compliance}
+}
+
+// Project implements Constraint.
+func (c *Distance) Project(dt float64) {
+	d := c.B.Position.Sub(c.A.Position)
+	length := d.Magnitude()
+	if length == 0 {
+		return
+	}
+	n := d.Scale(1 / length)
+	project([]*rigidbody.RigidBody{c.A, c.B}, []vector.Vector{n.Scale(-1), n}, length-c.Rest, c.Compliance, dt)
+}
+
+// Bending keeps the angle at B between A and C at Rest, in radians, so a chain
+// of particles resists folding.
+type Bending struct {
+	A, B, C    *rigidbody.RigidBody
+	Rest       float64
+	Compliance float64
+}
+
+// NewBending creates a bending constraint at the particles' current angle.
+func NewBending(a, b, c *rigidbody.RigidBody, compliance float64) *Bending {
+	return &Bending{A: a, B: b, C: c, Rest: angleAt(a.Position, b.Position, c.Position), Compliance: compliance}
+}
+
+// Project implements Constraint.
+func (c *Bending) Project(dt float64) {
+	u := c.A.Position.Sub(c.B.Position)
+	v := c.C.Position.Sub(c.B.Position)
+	uu, vv := u.InnerProduct(u), v.InnerProduct(v)
+	if uu == 0 || vv == 0 {
+		return
+	}
+	gradA := vector.Vector{X: u.Y, Y: -u.X}.Scale(1 / uu)
+	gradC := vector.Vector{X: -v.Y, Y: v.X}.Scale(1 / vv)
+	gradB := gradA.Add(gradC).Scale(-1)
+	angle := angleAt(c.A.Position, c.B.Position, c.C.Position) - c.Rest
+	angle = math.Remainder(angle, 2*math.Pi)
+	project([]*rigidbody.RigidBody{c.A, c.B, c.C}, []vector.Vector{gradA, gradB, gradC}, angle, c.Compliance, dt)
+}
+
+// angleAt returns the signed angle at b from the direction of a to that of c.
+func angleAt(a, b, c vector.Vector) float64 {
+	u, v := a.Sub(b), c.Sub(b)
+	return math.Atan2(u.Cross(v), u.InnerProduct(v))
+}
+
+// Area keeps the signed area enclosed by a loop of particles at Rest, so a
+// closed soft body keeps its volume.
+type Area struct {
+	Particles  []*rigidbody.RigidBody
+	Rest       float64
+	Compliance float64
+}
+
+// NewArea creates an area constraint at the loop's current area.
+func NewArea(particles []*rigidbody.RigidBody, compliance float64) *Area {
+	c := &Area{Particles: particles, Compliance: compliance}
+	c.Rest = polygon.SignedArea(c.positions())
+	return c
+}
+
+func (c *Area) positions() []vector.Vector {
+	positions := make([]vector.Vector, len(c.Particles))
+	for i, p := range c.Particles {
+		positions[i] = p.Position
+	}
+	return positions
+}
+
+// Project implements Constraint.
+func (c *Area) Project(dt float64) {
+	positions := c.positions()
+	n := len(positions)
+	grads := make([]vector.Vector, n)
+	for i := range positions {
+		prev := positions[(i-1+n)%n]
+		next := positions[(i+1)%n]
+		grads[i] = vector.Vector{X: next.Y - prev.Y, Y: prev.X - next.X}.Scale(0.5)
+	}
+	project(c.Particles, grads, polygon.SignedArea(positions)-c.Rest, c.Compliance, dt)
+}
+
+// project moves particles along the gradients of a constraint with the given
+// value, by the XPBD update for a substep of dt.
+func project(particles []*rigidbody.RigidBody, grads []vector.Vector, value, compliance, dt float64) {
+	w := 0.0
+	for i, p := range particles {
+		w += p.InverseMass() * grads[i].InnerProduct(grads[i])
+	}
+	alpha := compliance / (dt * dt)
+	if w+alpha == 0 {
+		return
+	}
+	lambda := -value / (w + alpha)
+	for i, p := range particles {
+		p.Position = p.Position.Add(grads[i].Scale(lambda * p.InverseMass()))
+	}
+}
+
+// Attachment ties a particle to a point on a rigid body, such as a crate
+// hanging from a rope. The body is pulled back as well, turning about its
+// centre, unless it is static or kinematic. A dynamic body must be added to
+// the solver like a particle, so the solver moves it and works out its
+// velocities.
+type Attachment struct {
+	Particle   *rigidbody.RigidBody
+	Body       *rigidbody.RigidBody
+	Anchor     vector.Vector // Point on the body, in body space
+	Compliance float64
+}
+
+// NewAttachment ties a particle to the point of the body where the particle is
+// now.
+func NewAttachment(particle, body *rigidbody.RigidBody, compliance float64) *Attachment {
+	return &Attachment{Particle: particle, Body: body, Anchor: body.LocalPoint(particle.Position), Compliance: compliance}
+}
+
+// Project implements Constraint.
+func (c *Attachment) Project(dt float64) {
+	anchor := c.Body.WorldPoint(c.Anchor)
+	d := c.Particle.Position.Sub(anchor)
+	length := d.Magnitude()
+	if length == 0 {
+		return
+	}
+	n := d.Scale(1 / length)
+	r := anchor.Sub(c.Body.Position)
+	rn := r.Cross(n)
+	wp := c.Particle.InverseMass()
+	wb := c.Body.InverseMass() + c.Body.InverseInertia()*rn*rn
+	alpha := c.Compliance / (dt * dt)
+	if wp+wb+alpha == 0 {
+		return
+	}
+	lambda := -length / (wp + wb + alpha)
+	c.Particle.Position = c.Particle.Position.Add(n.Scale(lambda * wp))
+	if wb == 0 {
+		return
+	}
+	c.Body.Position = c.Body.Position.Sub(n.Scale(lambda * c.Body.InverseMass()))
+	c.Body.Angle -= lambda * rn * c.Body.InverseInertia()
+}
@@ -0,0 +1,218 @@
+package xpbd
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// RopeOptions configures NewRope. The zero value makes a limp, inextensible
+// rope of unit mass with loose ends, in a solver of its own with no gravity.
+type RopeOptions struct {
+	Solver        *Solver              // Solver the rope joins; a new one is made if nil
+	Mass          float64              // Mass of the whole rope, 1 if 0
+	Radius        float64              // Radius of the particles and capsules
+	Compliance    float64              // How much the links stretch, 0 for an inextensible rope
+	BendStiffness float64              // Resistance to bending, 0 for a limp rope
+	Capsules      bool                 // Also build a capsule body along each link, for other bodies to hit
+	Start, End    *rigidbody.RigidBody // Bodies the ends are tied to, at the ends' positions; nil for a loose end
+}
+
+// RopeLink is one segment of a rope: the distance constraint between two
+// neighbouring particles and, with RopeOptions.Capsules, the capsule along it.
+type RopeLink struct {
+	*Distance
+	Capsule *rigidbody.RigidBody
+	Cut     bool
+
+	laid capsulePose // How Update last laid the capsule
+}
+
+// capsulePose is where a capsule was laid and how it was moving.
+type capsulePose struct {
+	position, velocity vector.Vector
+	angularVelocity    float64
+}
+
+// Rope is a chain of particles joined by links, built by NewRope.
+type Rope struct {
+	Particles []*rigidbody.RigidBody // From the start of the rope to its end
+	Links     []*RopeLink
+	Solver    *Solver
+
+	bends       []*Bending
+	attachments []*Attachment
+}
+
+// NewRope builds a rope from start to end out of the given number of
+// segments, and adds it to the solver in opts. Ends tied to a dynamic body
+// add that body to the solver, which then moves it.
+func NewRope(start, end vector.Vector, segments int, opts RopeOptions) *Rope {
+	if segments < 1 {
+		segments = 1
+	}
+	solver := opts.Solver
+	if solver == nil {
+		solver = NewSolver(vector.Vector{})
+	}
+	mass := opts.Mass
+	if mass <= 0 {
+		mass = 1
+	}
+	r := &Rope{Solver: solver}
+	step := end.Sub(start).Scale(1 / float64(segments))
+	for i := 0; i <= segments; i++ {
+		r.Particles = append(r.Particles, NewParticle(start.Add(step.Scale(float64(i))), mass/float64(segments+1), opts.Radius))
+	}
+	solver.Add(r.Particles...)
+
+	for i := 0; i < segments; i++ {
+		link := &RopeLink{Distance: NewDistance(r.Particles[i], r.Particles[i+1], opts.Compliance)}
+		if opts.Capsules {
+			link.Capsule = newCapsule(link.A, link.B, link.Rest, opts.Radius)
+		}
+		r.Links = append(r.Links, link)
+		solver.AddConstraint(link.Distance)
+	}
+	if opts.BendStiffness > 0 {
+		for i := 1; i < segments; i++ {
+			bend := NewBending(r.Particles[i-1], r.Particles[i], r.Particles[i+1], 1/opts.BendStiffness)
+			r.bends = append(r.bends, bend)
+			solver.AddConstraint(bend)
+		}
+	}
+	r.attach(r.Particles[0], opts.Start)
+	r.attach(r.Particles[segments], opts.End)
+	r.Update()
+	return r
+}
+
+// newCapsule builds the capsule along a link, as heavy as the ends that are
+// free to move and as hard to turn as they are, so a hit on it moves them as
+// it would move the capsule. A link with both ends pinned has a kinematic
+// capsule.
+func newCapsule(a, b *rigidbody.RigidBody, length, radius float64) *rigidbody.RigidBody {
+	capsule := &rigidbody.RigidBody{Shape: "Capsule", Height: length, Radius: radius, Type: rigidbody.Kinematic}
+	mass := 0.0
+	for _, p := range []*rigidbody.RigidBody{a, b} {
+		if p.InverseMass() > 0 {
+			mass += p.Mass
+		}
+	}
+	if mass > 0 {
+		capsule.Type = rigidbody.Dynamic
+		capsule.SetMass(mass)
+		if length > 0 {
+			capsule.Inertia = mass * length * length / 4
+			capsule.InvInertia = 1 / capsule.Inertia
+		}
+	}
+	return capsule
+}
+
+// attach ties a particle to a body, adding a dynamic body to the solver.
+func (r *Rope) attach(p, body *rigidbody.RigidBody) {
+	if body == nil {
+		return
+	}
+	if body.IsDynamic() {
+		found := false
+		for _, q := range r.Solver.Particles {
+			found = found || q == body
+		}
+		if !found {
+			r.Solver.Add(body)
+		}
+	}
+	a := NewAttachment(p, body, 0)
+	r.attachments = append(r.attachments, a)
+	r.Solver.AddConstraint(a)
+}
+
+// Step passes what other bodies did to the capsules on to the particles,
+// moves the rope's solver by dt and lays the capsules along the links again.
+// When several ropes share a solver, call Collect on each rope, step the
+// solver once and then call Update on each rope.
+func (r *Rope) Step(dt float64) {
+	r.Collect()
+	r.Solver.Step(dt)
+	r.Update()
+}
+
+// Collect passes how much the velocities of the capsules changed since Update
+// laid them on to the ends of their links. Only the velocities are passed on:
+// the links would pull a pushed particle back into line and fling it. Collide
+// the capsules with other bodies as dynamic bodies, such as with
+// collision.Resolve, but leave moving them to the rope.
+func (r *Rope) Collect() {
+	for _, l := range r.Links {
+		if l.Capsule == nil || l.Cut || !l.Capsule.IsDynamic() {
+			continue
+		}
+		c := l.Capsule
+		dv := c.Velocity.Sub(l.laid.velocity)
+		dw := c.AngularVelocity - l.laid.angularVelocity
+		for _, p := range []*rigidbody.RigidBody{l.A, l.B} {
+			if p.InverseMass() == 0 {
+				continue
+			}
+			arm := p.Position.Sub(l.laid.position)
+			p.Velocity = p.Velocity.Add(dv).Add(vector.CrossScalar(dw, arm))
+		}
+	}
+}
+
+// Update lays every capsule along its link, moving with it.
+func (r *Rope) Update() {
+	for _, l := range r.Links {
+		if l.Capsule == nil {
+			continue
+		}
+		d := l.B.Position.Sub(l.A.Position)
+		l.Capsule.Position = l.A.Position.Add(d.Scale(0.5))
+		l.Capsule.Angle = math.Atan2(d.Y, d.X) - math.Pi/2 // The capsule runs along its local Y
+		l.Capsule.Height = d.Magnitude()
+		l.Capsule.Velocity = l.A.Velocity.Add(l.B.Velocity).Scale(0.5)
+		if length := d.InnerProduct(d); length > 0 {
+			l.Capsule.AngularVelocity = d.Cross(l.B.Velocity.Sub(l.A.Velocity)) / length
+		}
+		l.laid = capsulePose{l.Capsule.Position, l.Capsule.Velocity, l.Capsule.AngularVelocity}
+	}
+}
+
+// Cut cuts the rope at a link, dropping its constraint and any bending that
+// spans it. It reports whether the link was still whole.
+func (r *Rope) Cut(link int) bool {
+	if link < 0 || link >= len(r.Links) || r.Links[link].Cut {
+		return false
+	}
+	r.Links[link].Cut = true
+	dropped := map[Constraint]bool{r.Links[link].Distance: true}
+	for _, b := range r.bends {
+		// The bend at particle i spans links i-1 and i
+		if b.B == r.Particles[link] || b.B == r.Particles[link+1] {
+			dropped[b] = true
+		}
+	}
+	kept := r.Solver.Constraints[:0]
+	for _, c := range r.Solver.Constraints {
+		if !dropped[c] {
+			kept = append(kept, c)
+		}
+	}
+	r.Solver.Constraints = kept
+	return true
+}
+
+// Capsules returns the capsules of the links that are still whole, for other
+// bodies to collide with.
+func (r *Rope) Capsules() []*rigidbody.RigidBody {
+	var capsules []*rigidbody.RigidBody
+	for _, l := range r.Links {
+		if l.Capsule != nil && !l.Cut {
+			capsules = append(capsules, l.Capsule)
+		}
+	}
+	return capsules
+}
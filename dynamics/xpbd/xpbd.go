@@ -0,0 +1,217 @@
+package xpbd
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/pkg/material"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Solver moves particles with extended position-based dynamics (XPBD). Each
+// step is split into substeps; every substep predicts where the particles
+// go, moves them back onto their constraints and then works out their
+// velocities from how far they moved. Stiff chains and cloth stay stable at
+// time steps where springs would blow up.
+//
+// Particles are rigid bodies, usually small circles made with NewParticle.
+// Their Position, Angle and velocities are moved by the solver; static bodies
+// and bodies without mass are pinned in place. Contacts with Colliders and
+// between particles are Collision constraints with ContactCompliance.
+type Solver struct {
+	Particles         []*rigidbody.RigidBody
+	Constraints       []Constraint
+	Colliders         []*rigidbody.RigidBody // Bodies the particles collide with; dynamic ones are moved by the solver too, so do not step them elsewhere
+	Gravity           vector.Vector          // Acceleration applied to every particle, such as {0, 98}
+	Substeps          int                    // Substeps per Step, 10 if 0
+	SelfCollide       bool                   // Keep the particles from overlapping each other
+	ContactCompliance float64                // How far contacts give, 0 for hard contacts
+	Materials         *material.Table        // Friction of chosen material pairs in contacts, nil for none
+
+	bodies        []*rigidbody.RigidBody // Particles and dynamic colliders
+	collider      []bool                 // Whether each of bodies is also a collider
+	grid          map[cell][]int         // Particles by cell, for SelfCollide
+	cells         []cell                 // Cell of each particle
+	previous      []vector.Vector
+	previousAngle []float64
+}
+
+// NewSolver creates a solver with the given gravity and 10 substeps per step.
+func NewSolver(gravity vector.Vector) *Solver {
+	return &Solver{Gravity: gravity, Substeps: 10}
+}
+
+// NewParticle creates a dynamic circle to use as a particle.
+func NewParticle(position vector.Vector, mass, radius float64) *rigidbody.RigidBody {
+	p := &rigidbody.RigidBody{
+		Position: position,
+		Shape:    "Circle",
+		Radius:   radius,
+		Type:     rigidbody.Dynamic,
+	}
+	p.SetMass(mass)
+	return p
+}
+
+// Add adds particles to the solver.
+func (s *Solver) Add(particles ...*rigidbody.RigidBody) {
+	s.Particles = append(s.Particles, particles...)
+}
+
+// AddConstraint adds constraints to the solver.
+func (s *Solver) AddConstraint(constraints ...Constraint) {
+	s.Constraints = append(s.Constraints, constraints...)
+}
+
+// Step advances the particles, and the dynamic colliders, by dt.
+func (s *Solver) Step(dt float64) {
+	substeps := s.Substeps
+	if substeps <= 0 {
+		substeps = 10
+	}
+	if dt <= 0 {
+		return
+	}
+	h := dt / float64(substeps)
+	s.gather()
+
+	for step := 0; step < substeps; step++ {
+		for i, p := range s.bodies {
+			s.previous[i], s.previousAngle[i] = p.Position, p.Angle
+			if p.InverseMass() == 0 {
+				continue
+			}
+			p.Velocity = p.Velocity.Add(s.Gravity.Scale(h))
+			p.Position = p.Position.Add(p.Velocity.Scale(h))
+			p.Angle += p.AngularVelocity * h
+		}
+		for _, c := range s.Constraints {
+			c.Project(h)
+		}
+		if s.SelfCollide {
+			s.collideParticles(h)
+		}
+		s.collideBodies(h)
+		for i, p := range s.bodies {
+			if p.InverseMass() == 0 {
+				continue
+			}
+			p.Velocity = p.Position.Sub(s.previous[i]).Scale(1 / h)
+			p.AngularVelocity = (p.Angle - s.previousAngle[i]) / h
+		}
+	}
+}
+
+// gather lists the bodies the solver moves: the particles, then the dynamic
+// colliders that are not particles too.
+func (s *Solver) gather() {
+	s.bodies = append(s.bodies[:0], s.Particles...)
+	for _, body := range s.Colliders {
+		if body.IsDynamic() && s.index(body) < 0 {
+			s.bodies = append(s.bodies, body)
+		}
+	}
+	s.collider = s.collider[:0]
+	for range s.bodies {
+		s.collider = append(s.collider, false)
+	}
+	for _, body := range s.Colliders {
+		if i := s.index(body); i >= 0 {
+			s.collider[i] = true
+		}
+	}
+	if cap(s.previous) < len(s.bodies) {
+		s.previous = make([]vector.Vector, len(s.bodies))
+		s.previousAngle = make([]float64, len(s.bodies))
+	}
+	s.previous = s.previous[:len(s.bodies)]
+	s.previousAngle = s.previousAngle[:len(s.bodies)]
+}
+
+// index returns where a body is in the bodies the solver moves, or -1.
+func (s *Solver) index(body *rigidbody.RigidBody) int {
+	for i, b := range s.bodies {
+		if b == body {
+			return i
+		}
+	}
+	return -1
+}
+
+// contact returns the contact constraint between a and b, which remembers
+// where they started the substep.
+func (s *Solver) contact(a, b *rigidbody.RigidBody, i, j int) Collision {
+	c := Collision{A: a, B: b, Compliance: s.ContactCompliance, Materials: s.Materials, startA: a.Position, startB: b.Position, startAngleB: b.Angle}
+	if i >= 0 {
+		c.startA = s.previous[i]
+	}
+	if j >= 0 {
+		c.startB, c.startAngleB = s.previous[j], s.previousAngle[j]
+	}
+	return c
+}
+
+// collideBodies resolves the contacts of the particles and the dynamic
+// colliders with the colliders, each pair once.
+func (s *Solver) collideBodies(h float64) {
+	for _, body := range s.Colliders {
+		j := s.index(body)
+		for i, a := range s.bodies {
+			if j == i || j >= 0 && j < i && s.collider[i] || a.InverseMass()+body.InverseMass() == 0 {
+				continue
+			}
+			c := s.contact(a, body, i, j)
+			c.Project(h)
+		}
+	}
+}
+
+type cell struct{ X, Y int }
+
+// collideParticles resolves the contacts between the particles. They are
+// sorted into a grid of cells as wide as the largest particle, so only
+// particles in neighbouring cells can touch.
+func (s *Solver) collideParticles(h float64) {
+	size := 0.0
+	for _, p := range s.Particles {
+		size = max(size, 2*p.Radius)
+	}
+	if size == 0 {
+		return
+	}
+	cellOf := func(v vector.Vector) cell {
+		return cell{int(math.Floor(v.X / size)), int(math.Floor(v.Y / size))}
+	}
+	if s.grid == nil {
+		s.grid = make(map[cell][]int)
+	}
+	for k, ids := range s.grid {
+		if len(ids) == 0 {
+			delete(s.grid, k) // Empty since the last substep, so nothing is left there
+		} else {
+			s.grid[k] = ids[:0]
+		}
+	}
+	s.cells = s.cells[:0]
+	for i, p := range s.Particles {
+		k := cellOf(p.Position)
+		s.grid[k] = append(s.grid[k], i)
+		s.cells = append(s.cells, k)
+	}
+
+	for i, a := range s.Particles {
+		k := s.cells[i]
+		for x := k.X - 1; x <= k.X+1; x++ {
+			for y := k.Y - 1; y <= k.Y+1; y++ {
+				for _, j := range s.grid[cell{x, y}] {
+					b := s.Particles[j]
+					if j <= i || a.InverseMass()+b.InverseMass() == 0 || vector.Distance(a.Position, b.Position) >= a.Radius+b.Radius {
+						continue
+					}
+					c := s.contact(a, b, i, j)
+					c.Project(h)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package xpbd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudransh61/Physix-go/pkg/polygon"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+func TestDistanceHoldsUnderGravity(t *testing.T) {
+	s := NewSolver(vector.Vector{X: 0, Y: 98})
+	pin := NewParticle(vector.Vector{X: 0, Y: 0}, 0, 1)
+	bob := NewParticle(vector.Vector{X: 50, Y: 0}, 1, 1)
+	s.Add(pin, bob)
+	rod := NewDistance(pin, bob, 0)
+	s.AddConstraint(rod)
+	for i := 0; i < 600; i++ {
+		s.Step(1.0 / 60)
+		if length := vector.Distance(pin.Position, bob.Position); math.Abs(length-rod.Rest) > 1e-3*rod.Rest {
+			t.Fatalf("step %d: rod is %v long, want %v", i, length, rod.Rest)
+		}
+	}
+	if pin.Position != (vector.Vector{}) {
+		t.Errorf("pinned particle moved to %v", pin.Position)
+	}
+}
+
+func TestAreaKeepsItsArea(t *testing.T) {
+	s := NewSolver(vector.Vector{X: 0, Y: 98})
+	var loop []*rigidbody.RigidBody
+	for i := 0; i < 12; i++ {
+		angle := 2 * math.Pi * float64(i) / 12
+		p := NewParticle(vector.Vector{X: 20 * math.Cos(angle), Y: 20 * math.Sin(angle)}, 1, 1)
+		loop = append(loop, p)
+		s.Add(p)
+	}
+	for i := range loop {
+		s.AddConstraint(NewDistance(loop[i], loop[(i+1)%len(loop)], 1e-4))
+	}
+	area := NewArea(loop, 0)
+	s.AddConstraint(area)
+	// Squash the loop from the sides
+	for _, p := range loop {
+		p.Velocity = vector.Vector{X: -p.Position.X * 5, Y: 0}
+	}
+	for i := 0; i < 120; i++ {
+		s.Step(1.0 / 60)
+	}
+	positions := make([]vector.Vector, len(loop))
+	for i, p := range loop {
+		positions[i] = p.Position
+	}
+	if got := polygon.SignedArea(positions); math.Abs(got-area.Rest) > 1e-3*math.Abs(area.Rest) {
+		t.Errorf("area = %v, want %v", got, area.Rest)
+	}
+}
+
+func TestParticleRestsOnGround(t *testing.T) {
+	s := NewSolver(vector.Vector{X: 0, Y: 98})
+	ground := &rigidbody.RigidBody{
+		Position: vector.Vector{X: 0, Y: 100},
+		Shape:    "Rectangle",
+		Width:    400,
+		Height:   20,
+		Type:     rigidbody.Static,
+	}
+	s.Colliders = append(s.Colliders, ground)
+	p := NewParticle(vector.Vector{X: 0, Y: 50}, 1, 5)
+	s.Add(p)
+	for i := 0; i < 600; i++ {
+		s.Step(1.0 / 60)
+	}
+	// The top of the ground is at 90, so the particle rests at 85
+	if math.Abs(p.Position.Y-85) > 0.05 {
+		t.Errorf("particle rests at %v, want 85", p.Position.Y)
+	}
+	if speed := p.Velocity.Magnitude(); speed > 0.1 {
+		t.Errorf("particle still moves at %v", speed)
+	}
+	if ground.Position != (vector.Vector{X: 0, Y: 100}) {
+		t.Errorf("static ground moved to %v", ground.Position)
+	}
+}
+
+func TestSelfCollideSeparatesParticles(t *testing.T) {
+	s := NewSolver(vector.Vector{})
+	s.SelfCollide = true
+	a := NewParticle(vector.Vector{X: 0, Y: 0}, 1, 2)
+	b := NewParticle(vector.Vector{X: 1, Y: 0}, 1, 2)
+	s.Add(a, b)
+	s.Step(1.0 / 60)
+	if d := vector.Distance(a.Position, b.Position); d < 4-1e-6 {
+		t.Errorf("particles are %v apart, want at least 4", d)
+	}
+	// Equal masses are pushed apart equally
+	if mid := a.Position.Add(b.Position).Scale(0.5); math.Abs(mid.X-0.5) > 1e-9 {
+		t.Errorf("midpoint moved to %v", mid)
+	}
+}
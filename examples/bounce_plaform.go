@@ -32,8 +32,8 @@ func update() error {
 
 func draw(screen *ebiten.Image) {
 	// Draw the rectangle using the github.com/rudransh61/Physix-go engine's position
-	ebitenutil.DrawRect(screen, ball.Position.X, ball.Position.Y, ball.Width, ball.Height, color.RGBA{R: 0xff, G: 0, B: 0, A: 0xff})
-	ebitenutil.DrawRect(screen, platform.Position.X, platform.Position.Y, platform.Width, platform.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0xff})
+	ebitenutil.DrawRect(screen, ball.Position.X-ball.Width/2, ball.Position.Y-ball.Height/2, ball.Width, ball.Height, color.RGBA{R: 0xff, G: 0, B: 0, A: 0xff})
+	ebitenutil.DrawRect(screen, platform.Position.X-platform.Width/2, platform.Position.Y-platform.Height/2, platform.Width, platform.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0xff})
 }
 
 func main() {
@@ -43,7 +43,7 @@ func main() {
 
 	// Initialize a rigid body with your github.com/rudransh61/Physix-go engine
 	ball = &rigidbody.RigidBody{
-		Position: vector.Vector{X: 400, Y: 100},
+		Position: vector.Vector{X: 425, Y: 125},
 		Velocity: vector.Vector{X: 0, Y: 2},
 		Mass:     1,
 		Force : vector.Vector{X: 0, Y: 5},
@@ -54,9 +54,8 @@ func main() {
 	}
 
 	platform = &rigidbody.RigidBody{
-		Position : vector.Vector{X:100 , Y:600},
+		Position : vector.Vector{X:600 , Y:625},
 		Velocity : vector.Vector{X:0,Y:0},
-		Mass : rigidbody.Infinite_mass,
 		IsMovable: false,
 		Shape: "Rectangle",
 		Width : 1000,
@@ -57,10 +57,7 @@ func checkwall(ball *rigidbody.RigidBody) {
 }
 
 func CheckBall(rect1, rect2 *rigidbody.RigidBody) bool {
-	left1, top1, right1, bottom1 := rect1.Position.X, rect1.Position.Y, rect1.Position.X+rect1.Width, rect1.Position.Y+rect1.Height
-	left2, top2, right2, bottom2 := rect2.Position.X, rect2.Position.Y, rect2.Position.X+rect2.Width, rect2.Position.Y+rect2.Height
-
-	return right1 > left2 && left1 < right2 && bottom1 > top2 && top1 < bottom2
+	return collision.RectangleCollided(rect1, rect2)
 }
 
 func update() error {
@@ -92,9 +89,9 @@ func update() error {
 }
 
 func draw(screen *ebiten.Image) {
-	ebitenutil.DrawRect(screen, ball.Position.X, ball.Position.Y, ball.Width, ball.Height, color.RGBA{R: 0xff, G: 0, B: 0, A: 0xff})
-	ebitenutil.DrawRect(screen, ball2.Position.X, ball2.Position.Y, ball2.Width, ball2.Height, color.RGBA{R: 0, G: 0, B: 0xff, A: 0xff})
-	ebitenutil.DrawRect(screen, ball3.Position.X, ball3.Position.Y, ball3.Width, ball3.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0xff})
+	ebitenutil.DrawRect(screen, ball.Position.X-ball.Width/2, ball.Position.Y-ball.Height/2, ball.Width, ball.Height, color.RGBA{R: 0xff, G: 0, B: 0, A: 0xff})
+	ebitenutil.DrawRect(screen, ball2.Position.X-ball2.Width/2, ball2.Position.Y-ball2.Height/2, ball2.Width, ball2.Height, color.RGBA{R: 0, G: 0, B: 0xff, A: 0xff})
+	ebitenutil.DrawRect(screen, ball3.Position.X-ball3.Width/2, ball3.Position.Y-ball3.Height/2, ball3.Width, ball3.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0xff})
 
 	//Boundary
 	ebitenutil.DrawRect(screen, 690.0, 100.0, 10, 600, color.RGBA{R: 0, G: 0xff, B: 0, A: 0})
@@ -110,7 +107,7 @@ func main() {
 
 	// Initialize a rigid body with your github.com/rudransh61/Physix-go engine
 	ball = &rigidbody.RigidBody{
-		Position:  vector.Vector{X: 100, Y: 200},
+		Position:  vector.Vector{X: 150, Y: 245},
 		Velocity:  vector.Vector{X: 50, Y: -50},
 		Mass:      10.0,
 		Shape:     "Rectangle",
@@ -119,7 +116,7 @@ func main() {
 		IsMovable: true,
 	}
 	ball2 = &rigidbody.RigidBody{
-		Position:  vector.Vector{X: 400, Y: 300},
+		Position:  vector.Vector{X: 435, Y: 335},
 		Velocity:  vector.Vector{X: 60, Y: 50},
 		Mass:      20.0,
 		Shape:     "Rectangle",
@@ -128,7 +125,7 @@ func main() {
 		IsMovable: true,
 	}
 	ball3 = &rigidbody.RigidBody{
-		Position:  vector.Vector{X: 400, Y: 400},
+		Position:  vector.Vector{X: 450, Y: 435},
 		Velocity:  vector.Vector{X: -30, Y: 50},
 		Mass:      30.0,
 		Shape:     "Rectangle",
@@ -101,7 +101,7 @@ func draw(screen *ebiten.Image) {
 			c = color.RGBA{R: 0, G: 0xff, B: 0, A: 0xff} // Green color
 		}
 		// Draw the ball
-		ebitenutil.DrawRect(screen, ball.Position.X, ball.Position.Y, ball.Width, ball.Height, c)
+		ebitenutil.DrawRect(screen, ball.Position.X-ball.Width/2, ball.Position.Y-ball.Height/2, ball.Width, ball.Height, c)
 	}
 
 	// Draw boundaries
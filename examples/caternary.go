@@ -5,9 +5,8 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	physix "github.com/rudransh61/Physix-go/dynamics/physics"
+	"github.com/rudransh61/Physix-go/dynamics/xpbd"
 	"github.com/rudransh61/Physix-go/pkg/rigidbody"
-	"github.com/rudransh61/Physix-go/pkg/spring"
 	"github.com/rudransh61/Physix-go/pkg/vector"
 )
 
@@ -15,84 +14,52 @@ const (
 	ScreenWidth   = 800
 	ScreenHeight  = 600
 	Gravity       = 98
-	Friction      = 98
 	BallRadius    = 10
-	BallMass      = 50
+	RopeMass      = 21 // Mass of the whole chain
 	SegmentCount  = 20
 	SegmentLength = 20
-	SpringK       = 100.0
-	StrongSpringK = 250 // Increase stiffness for end springs
-	Damping       = 5
+	Compliance    = 0.003 // How far the links stretch under the chain's weight
+	Damping       = 2     // Share of velocity lost per second
+	dt            = 1.0 / 60
 )
 
 type Game struct {
-	segments []*rigidbody.RigidBody
-	springs  []*spring.Spring
+	rope *xpbd.Rope
 }
 
 func (g *Game) initChain() {
-	startX := ScreenWidth/2 - (SegmentCount * SegmentLength / 2)
-	startY := 100
+	start := vector.Vector{X: ScreenWidth/2 - SegmentCount*SegmentLength/2, Y: 100}
+	end := start.Add(vector.Vector{X: SegmentCount * SegmentLength})
 
-	firstFixed := &rigidbody.RigidBody{
-		Position:  vector.Vector{X: float64(startX), Y: float64(startY)},
-		Velocity:  vector.Vector{X: 0, Y: 0},
-		Mass:      BallMass,
-		Radius:    BallRadius,
-		IsMovable: false,
+	// The ends are tied to two fixed hooks
+	hook := func(position vector.Vector) *rigidbody.RigidBody {
+		return &rigidbody.RigidBody{Position: position, Shape: "Circle", Radius: BallRadius, Type: rigidbody.Static}
 	}
-	g.segments = append(g.segments, firstFixed)
-
-	for i := 1; i < SegmentCount; i++ {
-		segment := &rigidbody.RigidBody{
-			Position:  vector.Vector{X: float64(startX + i*SegmentLength), Y: float64(startY)},
-			Velocity:  vector.Vector{X: 0, Y: 0},
-			Mass:      BallMass,
-			Radius:    BallRadius,
-			IsMovable: true,
-		}
-		g.segments = append(g.segments, segment)
-
-		// Apply strong spring only at the first and last connections
-		k := SpringK
-		if i == 1 || i == SegmentCount-1 {
-			k = StrongSpringK
-		}
-		g.springs = append(g.springs, spring.NewSpring(g.segments[i-1], segment, k, Damping))
-	}
-
-	lastFixed := &rigidbody.RigidBody{
-		Position:  vector.Vector{X: float64(startX + SegmentCount*SegmentLength), Y: float64(startY)},
-		Velocity:  vector.Vector{X: 0, Y: 0},
-		Mass:      BallMass,
-		Radius:    BallRadius,
-		IsMovable: false,
-	}
-	g.segments = append(g.segments, lastFixed)
-	g.springs = append(g.springs, spring.NewSpring(g.segments[len(g.segments)-2], lastFixed, StrongSpringK, Damping))
+	g.rope = xpbd.NewRope(start, end, SegmentCount, xpbd.RopeOptions{
+		Solver:     xpbd.NewSolver(vector.Vector{X: 0, Y: Gravity}),
+		Mass:       RopeMass,
+		Radius:     BallRadius,
+		Compliance: Compliance,
+		Start:      hook(start),
+		End:        hook(end),
+	})
 }
 
 func (g *Game) Update() error {
-	for _, segment := range g.segments {
-		if segment.IsMovable {
-			physix.ApplyForce(segment, vector.Vector{X: 0, Y: Gravity}.Sub(segment.Velocity.Scale(Friction)), 0.1)
-		}
-	}
-
-	for _, s := range g.springs {
-		s.ApplyForce()
+	g.rope.Step(dt)
+	for _, p := range g.rope.Particles {
+		p.Velocity = p.Velocity.Scale(1 - Damping*dt)
 	}
-
 	return nil
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
-	for _, segment := range g.segments {
-		ebitenutil.DrawCircle(screen, segment.Position.X, segment.Position.Y, segment.Radius, color.RGBA{0, 255, 0, 255})
+	for _, p := range g.rope.Particles {
+		ebitenutil.DrawCircle(screen, p.Position.X, p.Position.Y, p.Radius, color.RGBA{0, 255, 0, 255})
 	}
 
-	for i := 1; i < len(g.segments); i++ {
-		ebitenutil.DrawLine(screen, g.segments[i-1].Position.X, g.segments[i-1].Position.Y, g.segments[i].Position.X, g.segments[i].Position.Y, color.White)
+	for _, link := range g.rope.Links {
+		ebitenutil.DrawLine(screen, link.A.Position.X, link.A.Position.Y, link.B.Position.X, link.B.Position.Y, color.White)
 	}
 }
 
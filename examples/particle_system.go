@@ -5,11 +5,11 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/rudransh61/Physix-go/dynamics/collision"
 	"github.com/rudransh61/Physix-go/dynamics/physics"
+	"github.com/rudransh61/Physix-go/pkg/particles"
 	"github.com/rudransh61/Physix-go/pkg/rigidbody"
 	"github.com/rudransh61/Physix-go/pkg/vector"
 	"image/color"
-	// "math"
-	"math/rand"
+	"math"
 	// "fmt"
 )
 
@@ -21,6 +21,7 @@ var (
 	left *rigidbody.RigidBody
 	dt    = 0.001
 	e = 1.0
+	seed = int64(1) // Same seed, same starting balls
 )
 
 const (
@@ -98,10 +99,10 @@ func draw(screen *ebiten.Image) {
 	}
 
 	//Boundary
-	ebitenutil.DrawRect(screen, right.Position.X, right.Position.Y, right.Width, right.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0}) // right
-	ebitenutil.DrawRect(screen, left.Position.X, left.Position.Y, left.Width, left.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0})  // left
-	ebitenutil.DrawRect(screen, up.Position.X, up.Position.Y, up.Width, up.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0})  // up
-	ebitenutil.DrawRect(screen, down.Position.X, down.Position.Y, down.Width, down.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0})  // down
+	ebitenutil.DrawRect(screen, right.Position.X-right.Width/2, right.Position.Y-right.Height/2, right.Width, right.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0}) // right
+	ebitenutil.DrawRect(screen, left.Position.X-left.Width/2, left.Position.Y-left.Height/2, left.Width, left.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0})  // left
+	ebitenutil.DrawRect(screen, up.Position.X-up.Width/2, up.Position.Y-up.Height/2, up.Width, up.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0})  // up
+	ebitenutil.DrawRect(screen, down.Position.X-down.Width/2, down.Position.Y-down.Height/2, down.Width, down.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0})  // down
 }
 
 func main() {
@@ -121,11 +122,18 @@ func main() {
 
 // initializeBalls initializes n balls with common properties
 func initializeBalls(n int) {
+	// Spawn the balls in a 200x200 box, moving down and to the right
+	emitter := particles.NewEmitter(vector.Vector{X: 300, Y: 300}, seed)
+	emitter.Extent = vector.Vector{X: 100, Y: 100}
+	emitter.Angle = particles.Range{Min: 0, Max: math.Pi / 2}
+	emitter.Speed = particles.Range{Min: 0, Max: 20}
+	emitter.Lifetime = particles.Range{} // They live for ever
+
 	balls = make([]*rigidbody.RigidBody, n)
-	for i := 0; i < n; i++ {
+	for i, p := range emitter.Emit(n) {
 		balls[i] = &rigidbody.RigidBody{
-			Position:  vector.Vector{X: float64(rand.Intn(200) + 200), Y: float64(rand.Intn(200) + 200)},
-			Velocity:  vector.Vector{X: float64(rand.Intn(20)), Y: float64(rand.Intn(20))},
+			Position:  p.Position,
+			Velocity:  p.Velocity,
 			Mass:      Mass,
 			Shape:     Shape,
 			Radius:    Radius,
@@ -133,7 +141,7 @@ func initializeBalls(n int) {
 		}
 	}
 	down = &rigidbody.RigidBody{
-		Position:  vector.Vector{X: 100, Y: 600},
+		Position:  vector.Vector{X: 355, Y: 605},
 		Velocity:  vector.Vector{X: 0, Y: 0},
 		Mass:      0,
 		Shape:     "Rectangle",
@@ -142,7 +150,7 @@ func initializeBalls(n int) {
 		IsMovable: false,
 	}
 	right = &rigidbody.RigidBody{
-		Position:  vector.Vector{X: 600, Y: 300},
+		Position:  vector.Vector{X: 605, Y: 600},
 		Velocity:  vector.Vector{X: 0, Y: 0},
 		Mass:      0,
 		Shape:     "Rectangle",
@@ -151,7 +159,7 @@ func initializeBalls(n int) {
 		IsMovable: false,
 	}
 	left = &rigidbody.RigidBody{
-		Position:  vector.Vector{X: 100, Y: 300},
+		Position:  vector.Vector{X: 105, Y: 600},
 		Velocity:  vector.Vector{X: 0, Y: 0},
 		Mass:      0,
 		Shape:     "Rectangle",
@@ -160,7 +168,7 @@ func initializeBalls(n int) {
 		IsMovable: false,
 	}
 	up = &rigidbody.RigidBody{
-		Position:  vector.Vector{X: 100, Y: 100},
+		Position:  vector.Vector{X: 355, Y: 105},
 		Velocity:  vector.Vector{X: 0, Y: 0},
 		Mass:      0,
 		Shape:     "Rectangle",
@@ -58,11 +58,16 @@ func update() error {
 	// Update the physics simulation
 	camY += ball.Velocity.Y * dt * 0.95
 	physix.ApplyForce(ball, ball.Force, dt)
-	physix.ApplyForce(platform1, ball.Force, dt)
-	physix.ApplyForce(platform2, ball.Force, dt)
-	physix.ApplyForce(platform3, ball.Force, dt)
 	ball.Force.Y = 5
 
+	// platform2 is a kinematic lift: it follows its velocity and turns around at the ends
+	physix.ApplyForce(platform2, vector.Vector{}, dt)
+	if platform2.Position.Y < 325 {
+		platform2.Velocity.Y = 5
+	} else if platform2.Position.Y > 575 {
+		platform2.Velocity.Y = -5
+	}
+
 	// Check for collision between ball and platforms
 	if collision.RectangleCollided(ball, platform1) {
 		collision.PreventRectangleOverlap(ball, platform1)
@@ -70,11 +75,11 @@ func update() error {
 	}
 	if collision.RectangleCollided(ball, platform2) {
 		collision.PreventRectangleOverlap(ball, platform2)
-		collision.BounceOnCollision(ball, platform1, 0.0)
+		collision.BounceOnCollision(ball, platform2, 0.0)
 	}
 	if collision.RectangleCollided(ball, platform3) {
 		collision.PreventRectangleOverlap(ball, platform3)
-		collision.BounceOnCollision(ball, platform1, 0.0)
+		collision.BounceOnCollision(ball, platform3, 0.0)
 	}
 
 	return nil
@@ -86,10 +91,10 @@ func draw(screen *ebiten.Image) {
 	op.GeoM.Translate(-camX, -camY)
 
 	// Draw ball and platforms with camera offset
-	ebitenutil.DrawRect(screen, ball.Position.X-camX, ball.Position.Y-camY, ball.Width, ball.Height, color.RGBA{R: 0xff, G: 0, B: 0, A: 0xff})
-	ebitenutil.DrawRect(screen, platform1.Position.X-camX, platform1.Position.Y-camY, platform1.Width, platform1.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0xff})
-	ebitenutil.DrawRect(screen, platform2.Position.X-camX, platform2.Position.Y-camY, platform2.Width, platform2.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0xff})
-	ebitenutil.DrawRect(screen, platform3.Position.X-camX, platform3.Position.Y-camY, platform3.Width, platform3.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0xff})
+	ebitenutil.DrawRect(screen, ball.Position.X-ball.Width/2-camX, ball.Position.Y-ball.Height/2-camY, ball.Width, ball.Height, color.RGBA{R: 0xff, G: 0, B: 0, A: 0xff})
+	ebitenutil.DrawRect(screen, platform1.Position.X-platform1.Width/2-camX, platform1.Position.Y-platform1.Height/2-camY, platform1.Width, platform1.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0xff})
+	ebitenutil.DrawRect(screen, platform2.Position.X-platform2.Width/2-camX, platform2.Position.Y-platform2.Height/2-camY, platform2.Width, platform2.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0xff})
+	ebitenutil.DrawRect(screen, platform3.Position.X-platform3.Width/2-camX, platform3.Position.Y-platform3.Height/2-camY, platform3.Width, platform3.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0xff})
 }
 
 func main() {
@@ -98,41 +103,38 @@ func main() {
 
 	// Initialize objects
 	ball = &rigidbody.RigidBody{
-		Position:  vector.Vector{X: 400, Y: 100},
+		Position:  vector.Vector{X: 412.5, Y: 122.5},
 		Velocity:  vector.Vector{X: 0, Y: 2},
 		Mass:      1,
 		Force:     vector.Vector{X: 0, Y: 5},
-		IsMovable: true,
+		Type:      rigidbody.Dynamic,
 		Shape:     "Rectangle",
 		Width:     25,
 		Height:    45,
 	}
 
 	platform1 = &rigidbody.RigidBody{
-		Position:  vector.Vector{X: 100, Y: 600},
+		Position:  vector.Vector{X: 200, Y: 625},
 		Velocity:  vector.Vector{X: 0, Y: 0},
-		Mass:      rigidbody.Infinite_mass,
-		IsMovable: false,
+		Type:      rigidbody.Static,
 		Shape:     "Rectangle",
 		Width:     200,
 		Height:    50,
 	}
 
 	platform2 = &rigidbody.RigidBody{
-		Position:  vector.Vector{X: 400, Y: 450},
-		Velocity:  vector.Vector{X: 0, Y: 0},
-		Mass:      rigidbody.Infinite_mass,
-		IsMovable: false,
+		Position:  vector.Vector{X: 500, Y: 475},
+		Velocity:  vector.Vector{X: 0, Y: -5},
+		Type:      rigidbody.Kinematic,
 		Shape:     "Rectangle",
 		Width:     200,
 		Height:    50,
 	}
 
 	platform3 = &rigidbody.RigidBody{
-		Position:  vector.Vector{X: 700, Y: 300},
+		Position:  vector.Vector{X: 800, Y: 325},
 		Velocity:  vector.Vector{X: 0, Y: 0},
-		Mass:      rigidbody.Infinite_mass,
-		IsMovable: false,
+		Type:      rigidbody.Static,
 		Shape:     "Rectangle",
 		Width:     200,
 		Height:    50,
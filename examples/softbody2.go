@@ -69,8 +69,8 @@ func draw(screen *ebiten.Image) {
 	}
 
 	// Draw platforms
-	ebitenutil.DrawRect(screen, platform1.Position.X, platform1.Position.Y, platform1.Width, platform1.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0xff})
-	ebitenutil.DrawRect(screen, platform2.Position.X, platform2.Position.Y, platform2.Width, platform2.Height, color.RGBA{R: 0, G: 0xff, B: 0xff, A: 0xff})
+	ebitenutil.DrawRect(screen, platform1.Position.X-platform1.Width/2, platform1.Position.Y-platform1.Height/2, platform1.Width, platform1.Height, color.RGBA{R: 0, G: 0xff, B: 0, A: 0xff})
+	ebitenutil.DrawRect(screen, platform2.Position.X-platform2.Width/2, platform2.Position.Y-platform2.Height/2, platform2.Width, platform2.Height, color.RGBA{R: 0, G: 0xff, B: 0xff, A: 0xff})
 }
 
 // Initialize simulation
@@ -94,8 +94,7 @@ func initializeSimulation() {
 
 	// Create platform1 (lower one)
 	platform1 = &rigidbody.RigidBody{
-		Position:  vector.Vector{X: 100, Y: 300},
-		Mass:      rigidbody.Infinite_mass,
+		Position:  vector.Vector{X: 300, Y: 310},
 		Shape:     "Rectangle",
 		Width:     400,
 		Height:    20,
@@ -104,8 +103,7 @@ func initializeSimulation() {
 
 	// Create platform2 (higher one)
 	platform2 = &rigidbody.RigidBody{
-		Position:  vector.Vector{X: 245, Y: 200},
-		Mass:      rigidbody.Infinite_mass,
+		Position:  vector.Vector{X: 445, Y: 202.5},
 		Shape:     "Rectangle",
 		Width:     400,
 		Height:    5,
@@ -2,16 +2,15 @@ package main
 
 import (
 	"image/color"
-	"math"
 	"math/rand"
-	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/rudransh61/Physix-go/dynamics/collision"
+	"github.com/rudransh61/Physix-go/pkg/particles"
 	"github.com/rudransh61/Physix-go/pkg/rigidbody"
 	"github.com/rudransh61/Physix-go/pkg/vector"
-	"github.com/rudransh61/Physix-go/pkg/broadphase"
+	"github.com/rudransh61/Physix-go/pkg/verlet"
 )
 
 // PVEBody extends rigidbody.RigidBody with Heat field
@@ -19,52 +18,49 @@ type PVEBody struct {
 	*rigidbody.RigidBody
 	Heat  float64 // Heat of the particle
 	Color color.RGBA
+
+	particle *particles.Particle // Emitter particle the ball follows
+	point    *verlet.Particle    // Stands in for the ball in the spatial hash
 }
 
 var (
-	balls           []*PVEBody // Using PVEBody instead of rigidbody.RigidBody
-	dt              = 0.1
-	ticker          *time.Ticker
-	initialInterval = time.Second / 5 // Initial interval for adding particles
-	center          vector.Vector     // Center of the screen
-	limit           = 10000
-
-	// Broad-phase spatial hash
-	spatialHash *broadphase.SpatialHash
+	balls   []*PVEBody // Using PVEBody instead of rigidbody.RigidBody
+	dt      = 0.1
+	emitter *particles.Emitter                       // Spawns, ages, moves and recycles the particles
+	bodies  = make(map[*particles.Particle]*PVEBody) // Ball of each emitter particle, reused with it
+	colors  *rand.Rand                               // Picks their colours
+	seed    = int64(1)                               // Same seed, same simulation
+	center  vector.Vector                            // Center of the screen
+	limit   = 10000
+
+	// Broad-phase spatial hash, and the ball each of its points stands for
+	spatialHash *verlet.SpatialHash
+	owners      = make(map[*verlet.Particle]*PVEBody)
 )
 
 const (
 	Mass       = 1
 	Shape      = "Circle"
-	Radius     = 2     // Tiny particles
-	Friction   = 0.899 // Friction coefficient
-	Gravity    = 50    // Strength of gravity towards the center
+	Radius     = 2      // Tiny particles
+	Friction   = 0.899  // Friction coefficient
+	Gravity    = 50     // Strength of gravity towards the center
 	InitRadius = 1000.0 // Initial radius of particle distribution
 )
 
 var (
-	particlesAdded = 0
-	maxParticles   = 100000 // Maximum number of particles to add
+	maxParticles = 100000                               // Maximum number of particles alive at once
+	spawnRate    = 12.0                                 // Particles added per second of simulated time
+	lifetime     = particles.Range{Min: 600, Max: 1200} // Seconds of simulated time a particle shines for
 )
 
 func update() error {
-	// Add new particles until the maximum is reached
-	if particlesAdded < maxParticles {
-		select {
-		case <-ticker.C:
-			for i := 0; i < 14; i++ {
-				addParticle()
-			}
-		default:
-		}
-	}
-
 	// Clear spatial hash
 	spatialHash.Clear()
 
 	// Insert particles into the spatial hash
 	for _, ball := range balls {
-		spatialHash.Add(ball, ball.Position)
+		ball.point.Position = ball.Position
+		spatialHash.Insert(ball.point)
 	}
 
 	// Apply forces and update particles
@@ -82,10 +78,9 @@ func update() error {
 		ball := balls[i]
 
 		// Query nearby objects using spatial hash
-		nearbyObjects := spatialHash.Query(ball.Position)
-		for _, obj := range nearbyObjects {
-			other, ok := obj.(*PVEBody)
-			if !ok || other == ball {
+		for _, point := range spatialHash.Near(ball.Position, 2*Radius) {
+			other := owners[point]
+			if other == ball {
 				continue
 			}
 
@@ -96,6 +91,16 @@ func update() error {
 		}
 	}
 
+	// Hand the velocities to the emitter, which moves the particles on, ages
+	// them, recycles the burnt out ones and adds new ones
+	for _, ball := range balls {
+		ball.particle.Velocity = ball.Velocity
+	}
+	for _, p := range emitter.Update(dt) {
+		addParticle(p)
+	}
+	collectBalls()
+
 	return nil
 }
 
@@ -113,12 +118,14 @@ func main() {
 	// Set the center of the screen
 	center = vector.Vector{X: 400, Y: 400}
 
-	ticker = time.NewTicker(initialInterval)
+	emitter = particles.NewEmitter(center, seed)
+	emitter.Lifetime = lifetime
+	emitter.Max = maxParticles
+	colors = rand.New(rand.NewSource(seed))
 
 	// Initialize spatial hash with appropriate cell size
 	cellSize := 2.0 * Radius // Adjust cell size based on particle size
-	screenWidth, screenHeight := ebiten.WindowSize()
-	spatialHash = broadphase.NewSpatialHash(cellSize, float64(screenWidth), float64(screenHeight))
+	spatialHash = verlet.NewSpatialHash(cellSize)
 
 	// Initialize with a few particles
 	initializeBalls(10000)
@@ -130,53 +137,53 @@ func main() {
 
 func initializeBalls(n int) {
 	balls = make([]*PVEBody, 0, n)
-	for i := 0; i < n; i++ {
-		// Generate particles in a circular pattern around the center
-		angle := 2 * math.Pi * float64(i) / float64(n)
-		radius := rand.Float64() * InitRadius
-		x := center.X + radius*math.Cos(angle)
-		y := center.Y + radius*math.Sin(angle)
-		colorValue := uint8(rand.Int())
-		colorValue1 := uint8(rand.Int())
-		colorValue2 := uint8(rand.Int())
-		ball := &PVEBody{
-			RigidBody: &rigidbody.RigidBody{
-				Position:  vector.Vector{X: x, Y: y},
-				Velocity:  vector.Vector{X: 0, Y: 0}, // No initial velocity
-				Mass:      Mass,
-				Shape:     Shape,
-				Radius:    Radius,
-				IsMovable: true,
-			},
-			Color: color.RGBA{R: colorValue1, G: colorValue2, B: colorValue, A: 0xff},
-			Heat:  100.0, // Set initial heat value
-		}
-		balls = append(balls, ball)
+
+	// Start with a disc of particles around the center
+	emitter.Radius = InitRadius
+	for _, p := range emitter.Emit(n) {
+		addParticle(p)
 	}
-}
+	collectBalls()
 
-func addParticle() {
-	// Add particles dynamically
+	// Then keep adding them anywhere on the screen
 	screenWidth, screenHeight := ebiten.WindowSize()
-	x := rand.Float64() * float64(screenWidth)
-	y := rand.Float64() * float64(screenHeight)
-	colorValue := uint8(rand.Int())
-	colorValue1 := uint8(rand.Int())
-	colorValue2 := uint8(rand.Int())
-	ball := &PVEBody{
-		RigidBody: &rigidbody.RigidBody{
-			Position:  vector.Vector{X: x, Y: y},
-			Velocity:  vector.Vector{X: 0, Y: 0}, // No initial velocity
-			Mass:      Mass,
-			Shape:     Shape,
-			Radius:    Radius,
-			IsMovable: true,
-		},
-		Color: color.RGBA{R: colorValue1, G: colorValue2, B: colorValue, A: 0xff},
-		Heat:  100.0, // Set initial heat value
+	emitter.Extent = vector.Vector{X: float64(screenWidth) / 2, Y: float64(screenHeight) / 2}
+	emitter.Rate = spawnRate
+}
+
+// addParticle gives a newly spawned particle a fresh ball. The emitter reuses
+// particles once they burn out, and their balls are reused with them.
+func addParticle(p *particles.Particle) {
+	colorValue := uint8(colors.Int())
+	colorValue1 := uint8(colors.Int())
+	colorValue2 := uint8(colors.Int())
+	ball := bodies[p]
+	if ball == nil {
+		ball = &PVEBody{particle: p, point: &verlet.Particle{Radius: Radius, Mass: Mass}}
+		bodies[p] = ball
+		owners[ball.point] = ball
+	}
+	ball.RigidBody = &rigidbody.RigidBody{
+		Position:  p.Position,
+		Velocity:  p.Velocity,
+		Mass:      Mass,
+		Shape:     Shape,
+		Radius:    Radius,
+		IsMovable: true,
+	}
+	ball.Color = color.RGBA{R: colorValue1, G: colorValue2, B: colorValue, A: 0xff}
+	ball.Heat = 100.0 // Set initial heat value
+}
+
+// collectBalls lists the balls of the particles alive now, where the emitter
+// has moved them.
+func collectBalls() {
+	balls = balls[:0]
+	for _, p := range emitter.Particles() {
+		ball := bodies[p]
+		ball.Position, ball.Velocity = p.Position, p.Velocity
+		balls = append(balls, ball)
 	}
-	balls = append(balls, ball)
-	particlesAdded++
 }
 
 type Game struct{}
@@ -194,17 +201,15 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeigh
 }
 
 func ApplyForcePVE(body *PVEBody, force vector.Vector, dt float64) {
-	if body.IsMovable {
-		// Use Newton's second law: F = ma -> a = F/m
+	if body.IsDynamic() {
+		// Use Newton's second law: F = ma -> a = F * (1/m)
 		body.Force = force
-		acceleration := body.Force.Scale(1 / body.Mass)
+		acceleration := body.Force.Scale(body.InverseMass())
 
-		// Update velocity using acceleration and time step
+		// Update velocity using acceleration and time step; the emitter
+		// moves the particle on by it
 		body.Velocity = body.Velocity.Add(acceleration.Scale(dt))
 
-		// Update position using velocity and time step
-		body.Position = body.Position.Add(body.Velocity.Scale(dt))
-
 		body.Heat = body.Velocity.Scale(0.5).Magnitude()
 	}
 }
@@ -224,8 +229,8 @@ func resolveCollision(ball1, ball2 *rigidbody.RigidBody, balls []*PVEBody) {
 		repulsiveForce := moveDirection.Scale(repulsiveForceMagnitude)
 
 		// Apply the repulsive force to the velocities of the balls
-		ball1.Velocity = ball1.Velocity.Add(repulsiveForce.Scale(dt / ball1.Mass).Scale(0.9))
-		ball2.Velocity = ball2.Velocity.Add(repulsiveForce.Scale(-dt / ball2.Mass).Scale(0.9))
+		ball1.Velocity = ball1.Velocity.Add(repulsiveForce.Scale(dt * ball1.InverseMass()).Scale(0.9))
+		ball2.Velocity = ball2.Velocity.Add(repulsiveForce.Scale(-dt * ball2.InverseMass()).Scale(0.9))
 
 		// Adjust positions slightly to avoid sticking
 		correctionFactor := 0.5 // Adjust this factor as needed for desired effect
@@ -0,0 +1,140 @@
+package material
+
+import "math"
+
+// CombineMode decides how a property of two touching materials is mixed.
+// When two materials ask for different modes the later one in this list wins,
+// so Max beats Multiply, Multiply beats Min and Min beats Average.
+type CombineMode int
+
+const (
+	Average CombineMode = iota
+	Min
+	Multiply
+	Max
+)
+
+// Material describes the surface and bulk properties of a body.
+type Material struct {
+	StaticFriction     float64 // Friction coefficient while the surfaces are not sliding
+	DynamicFriction    float64 // Friction coefficient while the surfaces slide
+	Restitution        float64 // Bounciness, 0 is fully inelastic and 1 is fully elastic
+	Density            float64 // Mass per unit area
+	RollingResistance  float64 // Slows down rolling circles
+	Toughness          float64 // Impulse that fractures a body, 0 for bodies that never break
+	FrictionCombine    CombineMode
+	RestitutionCombine CombineMode
+}
+
+// Default is used for bodies that have no material. It mixes like the
+// materials of NewMaterial.
+var Default = &Material{
+	StaticFriction:     0.5,
+	DynamicFriction:    0.3,
+	Restitution:        0,
+	Density:            1,
+	FrictionCombine:    Average,
+	RestitutionCombine: Max,
+}
+
+// NewMaterial creates a material that averages friction and takes the larger restitution.
+func NewMaterial(staticFriction, dynamicFriction, restitution, density float64) *Material {
+	return &Material{
+		StaticFriction:     staticFriction,
+		DynamicFriction:    dynamicFriction,
+		Restitution:        restitution,
+		Density:            density,
+		FrictionCombine:    Average,
+		RestitutionCombine: Max,
+	}
+}
+
+// Combine mixes two values with the given mode.
+func Combine(a, b float64, mode CombineMode) float64 {
+	switch mode {
+	case Min:
+		return math.Min(a, b)
+	case Multiply:
+		return a * b
+	case Max:
+		return math.Max(a, b)
+	}
+	return (a + b) / 2
+}
+
+// Pair holds the mixed properties used when two materials touch.
+type Pair struct {
+	StaticFriction    float64
+	DynamicFriction   float64
+	Restitution       float64
+	RollingResistance float64
+}
+
+// Mix combines two materials with their combine modes. A nil material is
+// replaced by Default.
+func Mix(a, b *Material) Pair {
+	a, b = orDefault(a), orDefault(b)
+	friction := a.FrictionCombine
+	if b.FrictionCombine > friction {
+		friction = b.FrictionCombine
+	}
+	restitution := a.RestitutionCombine
+	if b.RestitutionCombine > restitution {
+		restitution = b.RestitutionCombine
+	}
+	return Pair{
+		StaticFriction:    Combine(a.StaticFriction, b.StaticFriction, friction),
+		DynamicFriction:   Combine(a.DynamicFriction, b.DynamicFriction, friction),
+		Restitution:       Combine(a.Restitution, b.Restitution, restitution),
+		RollingResistance: Combine(a.RollingResistance, b.RollingResistance, friction),
+	}
+}
+
+// Table overrides the mixed properties of chosen material pairs, such as ice
+// on rubber, and falls back to Mix for every other pair.
+type Table struct {
+	overrides map[[2]*Material]Pair
+}
+
+// NewTable creates an empty override table.
+func NewTable() *Table {
+	return &Table{overrides: make(map[[2]*Material]Pair)}
+}
+
+// Set overrides the properties used when a and b touch, in either order.
+func (t *Table) Set(a, b *Material, p Pair) {
+	a, b = orDefault(a), orDefault(b)
+	t.overrides[[2]*Material{a, b}] = p
+	t.overrides[[2]*Material{b, a}] = p
+}
+
+// Remove drops the override for a and b.
+func (t *Table) Remove(a, b *Material) {
+	a, b = orDefault(a), orDefault(b)
+	delete(t.overrides, [2]*Material{a, b})
+	delete(t.overrides, [2]*Material{b, a})
+}
+
+// Override returns the override for a and b, if there is one.
+func (t *Table) Override(a, b *Material) (Pair, bool) {
+	if t == nil {
+		return Pair{}, false
+	}
+	p, ok := t.overrides[[2]*Material{orDefault(a), orDefault(b)}]
+	return p, ok
+}
+
+// Mix returns the override for a and b if there is one, and Mix(a, b) otherwise.
+func (t *Table) Mix(a, b *Material) Pair {
+	if p, ok := t.Override(a, b); ok {
+		return p
+	}
+	return Mix(a, b)
+}
+
+func orDefault(m *Material) *Material {
+	if m == nil {
+		return Default
+	}
+	return m
+}
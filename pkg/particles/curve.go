@@ -0,0 +1,93 @@
+package particles
+
+import (
+	"image/color"
+	"math/rand"
+)
+
+// Range is an interval that values are drawn from evenly. A range with Min
+// equal to Max always gives that value.
+type Range struct {
+	Min, Max float64
+}
+
+func (r Range) sample(rng *rand.Rand) float64 {
+	if r.Min == r.Max {
+		return r.Min
+	}
+	return r.Min + (r.Max-r.Min)*rng.Float64()
+}
+
+// Key is a value at a point of a particle's life.
+type Key struct {
+	At    float64 // Share of the lifetime, from 0 to 1
+	Value float64
+}
+
+// Curve is a value that changes over a particle's life, made of keys in
+// order of At and joined by straight lines.
+type Curve []Key
+
+// At returns the value of the curve at life, from 0 to 1. Before the first
+// key and after the last, the value of the nearest key is kept.
+func (c Curve) At(life float64) float64 {
+	if len(c) == 0 {
+		return 0
+	}
+	if life <= c[0].At {
+		return c[0].Value
+	}
+	for i := 1; i < len(c); i++ {
+		if life <= c[i].At {
+			a, b := c[i-1], c[i]
+			if b.At == a.At {
+				return b.Value
+			}
+			return a.Value + (b.Value-a.Value)*(life-a.At)/(b.At-a.At)
+		}
+	}
+	return c[len(c)-1].Value
+}
+
+// ColorKey is a colour at a point of a particle's life.
+type ColorKey struct {
+	At    float64 // Share of the lifetime, from 0 to 1
+	Color color.RGBA
+}
+
+// Gradient is a colour that changes over a particle's life, made of keys in
+// order of At and blended between them.
+type Gradient []ColorKey
+
+// At returns the colour of the gradient at life, from 0 to 1, or white if the
+// gradient is empty. Before the first key and after the last, the colour of
+// the nearest key is kept.
+func (g Gradient) At(life float64) color.RGBA {
+	if len(g) == 0 {
+		return color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	}
+	if life <= g[0].At {
+		return g[0].Color
+	}
+	for i := 1; i < len(g); i++ {
+		if life <= g[i].At {
+			a, b := g[i-1], g[i]
+			if b.At == a.At {
+				return b.Color
+			}
+			t := (life - a.At) / (b.At - a.At)
+			return color.RGBA{
+				R: blend(a.Color.R, b.Color.R, t),
+				G: blend(a.Color.G, b.Color.G, t),
+				B: blend(a.Color.B, b.Color.B, t),
+				A: blend(a.Color.A, b.Color.A, t),
+			}
+		}
+	}
+	return g[len(g)-1].Color
+}
+
+// blend mixes two colour channels, t of the way from a to b.
+func blend(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+}
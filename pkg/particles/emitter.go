@@ -0,0 +1,167 @@
+package particles
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Particle is a short-lived point of an effect, such as a spark or a puff of
+// smoke.
+type Particle struct {
+	Position vector.Vector
+	Velocity vector.Vector
+	Age      float64    // Seconds since the particle was spawned
+	Lifetime float64    // Seconds the particle lives, 0 for ever
+	Size     float64    // Size now, after Emitter.SizeOverLife
+	Color    color.RGBA // Colour now, from Emitter.Color
+
+	size float64 // Size it was spawned with
+}
+
+// Life returns how far through its lifetime the particle is, from 0 to 1. A
+// particle that lives for ever stays at 0.
+func (p *Particle) Life() float64 {
+	if p.Lifetime <= 0 {
+		return 0
+	}
+	return math.Min(p.Age/p.Lifetime, 1)
+}
+
+// Emitter spawns particles at a steady rate or in bursts, moves them, and
+// recycles them once their lifetime is over. Its random source is seeded, so
+// the same seed and the same steps give the same particles. An Emitter made
+// without NewEmitter uses a source seeded with 0.
+type Emitter struct {
+	Position vector.Vector
+	Extent   vector.Vector // Half the width and height of the box particles spawn in
+	Radius   float64       // Radius of the disc particles spawn in, when Extent is zero
+	Rate     float64       // Particles spawned per second, 0 for bursts only
+	Max      int           // Most particles alive at once, 0 for no limit
+
+	Angle    Range // Direction of the initial velocity, in radians from the X axis towards Y
+	Speed    Range // Initial speed
+	Lifetime Range // Seconds a particle lives, 0 for ever
+	Size     Range // Size a particle spawns with
+
+	SizeOverLife Curve    // Scales the size over the particle's life, 1 if empty
+	Color        Gradient // Colour over the particle's life, white if empty
+
+	Gravity vector.Vector // Acceleration of every particle, such as {0, 98}
+	Drag    float64       // Share of velocity lost per second
+
+	rand      *rand.Rand
+	particles []*Particle // Alive ones first, then dead ones kept for reuse
+	alive     int
+	pending   float64 // Share of a particle owed by Rate
+}
+
+// NewEmitter creates an emitter at position with a random source seeded with
+// seed. Particles spawn in every direction, live for a second and have a size
+// of 1; set Rate, Speed and the rest to shape the effect.
+func NewEmitter(position vector.Vector, seed int64) *Emitter {
+	return &Emitter{
+		Position: position,
+		Angle:    Range{0, 2 * math.Pi},
+		Lifetime: Range{1, 1},
+		Size:     Range{1, 1},
+		rand:     rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Particles returns the particles alive now. The slice is only valid until
+// the next call to Update or Emit.
+func (e *Emitter) Particles() []*Particle {
+	return e.particles[:e.alive]
+}
+
+// Update ages and moves the particles by dt, recycles the ones whose lifetime
+// is over and spawns new ones at Rate. It returns the particles it spawned.
+func (e *Emitter) Update(dt float64) []*Particle {
+	if dt <= 0 {
+		return nil
+	}
+	drag := math.Max(1-e.Drag*dt, 0)
+	for i := 0; i < e.alive; {
+		p := e.particles[i]
+		p.Age += dt
+		if p.Lifetime > 0 && p.Age >= p.Lifetime {
+			// Swap it with the last live particle, to be reused
+			e.alive--
+			e.particles[i], e.particles[e.alive] = e.particles[e.alive], p
+			continue
+		}
+		p.Velocity = p.Velocity.Add(e.Gravity.Scale(dt)).Scale(drag)
+		p.Position = p.Position.Add(p.Velocity.Scale(dt))
+		e.style(p)
+		i++
+	}
+	e.pending += e.Rate * dt
+	n := int(e.pending)
+	e.pending -= float64(n)
+	return e.Emit(n)
+}
+
+// Emit spawns n particles at once, as a burst, and returns them. Fewer are
+// spawned if Max would be passed.
+func (e *Emitter) Emit(n int) []*Particle {
+	start := e.alive
+	for i := 0; i < n; i++ {
+		if e.Max > 0 && e.alive >= e.Max {
+			break
+		}
+		if e.alive == len(e.particles) {
+			e.particles = append(e.particles, &Particle{})
+		}
+		e.spawn(e.particles[e.alive])
+		e.alive++
+	}
+	return e.particles[start:e.alive]
+}
+
+// random returns the emitter's random source, making it on first use.
+func (e *Emitter) random() *rand.Rand {
+	if e.rand == nil {
+		e.rand = rand.New(rand.NewSource(0))
+	}
+	return e.rand
+}
+
+// spawn resets a particle as if new.
+func (e *Emitter) spawn(p *Particle) {
+	rng := e.random()
+	position := e.Position
+	switch {
+	case e.Extent != (vector.Vector{}):
+		position = position.Add(vector.Vector{
+			X: (2*rng.Float64() - 1) * e.Extent.X,
+			Y: (2*rng.Float64() - 1) * e.Extent.Y,
+		})
+	case e.Radius > 0:
+		// The square root spreads them evenly over the disc
+		r := e.Radius * math.Sqrt(rng.Float64())
+		angle := rng.Float64() * 2 * math.Pi
+		position = position.Add(vector.Vector{X: r * math.Cos(angle), Y: r * math.Sin(angle)})
+	}
+	angle := e.Angle.sample(rng)
+	speed := e.Speed.sample(rng)
+	*p = Particle{
+		Position: position,
+		Velocity: vector.Vector{X: speed * math.Cos(angle), Y: speed * math.Sin(angle)},
+		Lifetime: e.Lifetime.sample(rng),
+		size:     e.Size.sample(rng),
+	}
+	e.style(p)
+}
+
+// style sets a particle's size and colour for how far through its life it is.
+func (e *Emitter) style(p *Particle) {
+	life := p.Life()
+	p.Size = p.size
+	if len(e.SizeOverLife) > 0 {
+		p.Size *= e.SizeOverLife.At(life)
+	}
+	p.Color = e.Color.At(life)
+}
@@ -0,0 +1,460 @@
+package polygon
+
+import (
+	"math"
+	"sort"
+
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Region is an area bounded by an outline, less the holes inside it. The
+// boolean operations return outlines wound counter-clockwise and holes wound
+// clockwise in a y-up frame, and accept either winding.
+type Region struct {
+	Outline []vector.Vector
+	Holes   [][]vector.Vector
+}
+
+// Region returns the world-space outline of the polygon as a region.
+func (p *Polygon) Region() Region {
+	outline := make([]vector.Vector, len(p.Vertices))
+	copy(outline, p.WorldVertices())
+	return Region{Outline: CounterClockwise(outline)}
+}
+
+// Area returns the area of the region without its holes.
+func (r Region) Area() float64 {
+	area := Area(r.Outline)
+	for _, hole := range r.Holes {
+		area -= Area(hole)
+	}
+	return area
+}
+
+// Contains reports whether point lies in the region and not in one of its
+// holes.
+func (r Region) Contains(point vector.Vector) bool {
+	return insideRings(r.rings(), point)
+}
+
+// Union returns the area covered by a or b. The regions within each set must
+// not overlap one another; the results of these operations never do.
+func Union(a, b []Region) []Region {
+	return clip(a, b, false, false, false)
+}
+
+// Intersection returns the area covered by both a and b.
+func Intersection(a, b []Region) []Region {
+	return clip(a, b, true, true, false)
+}
+
+// Difference returns the area covered by a but not by b, such as terrain with
+// a crater cut out of it.
+func Difference(a, b []Region) []Region {
+	return clip(a, b, false, true, true)
+}
+
+// Xor returns the area covered by exactly one of a and b. The regions from
+// either side may share edges.
+func Xor(a, b []Region) []Region {
+	return append(Difference(a, b), Difference(b, a)...)
+}
+
+// clipNode is a vertex of an outline or hole being clipped. Crossings with the
+// other shape are spliced into the ring and linked to their twin on it.
+type clipNode struct {
+	point      vector.Vector
+	next       *clipNode
+	neighbor   *clipNode // Same crossing on the other shape
+	alpha      float64   // Distance of a crossing along its edge, from 0 to 1
+	isCrossing bool
+	keep       bool // Whether the edge leaving this node is in the result
+	visited    bool
+}
+
+// clip runs a Greiner-Hormann style clip of two region sets. Every ring is cut
+// where it crosses the other shape, the pieces of edge inside or outside the
+// other shape are kept, and the kept pieces are linked back into rings. With
+// both sets wound the same way the result comes out wound the same way too;
+// reversing b turns its inside edges into the boundary of a difference.
+func clip(a, b []Region, keepAInside, keepBInside, reverseB bool) []Region {
+	aRings := ringsOf(a, false)
+	bRings := ringsOf(b, reverseB)
+	if len(aRings) == 0 || len(bRings) == 0 {
+		if keepAInside || keepBInside && len(aRings) == 0 {
+			return nil // Intersecting with nothing, or cutting from nothing
+		}
+		return groupRings(append(aRings, bRings...))
+	}
+
+	// Nudge b until no vertex of either shape lies on the other's outline, so
+	// every crossing is a clean one. The nudge only decides which edges cross
+	// and which are kept; the result is traced along the shapes as given.
+	original := make([][]vector.Vector, len(bRings))
+	for i, ring := range bRings {
+		original[i] = append([]vector.Vector(nil), ring...)
+	}
+	drift := 0.0 // How far b has been nudged, at most
+	for attempt := 0; attempt < 8; attempt++ {
+		if !touching(aRings, bRings) {
+			break
+		}
+		step := 1e-6 * extent(aRings, bRings) * float64(attempt+1)
+		drift += step
+		angle := 2.399963 * float64(attempt+1) // Golden angle, to avoid repeating directions
+		offset := vector.Vector{X: step * math.Cos(angle), Y: step * math.Sin(angle)}
+		for _, ring := range bRings {
+			for i := range ring {
+				ring[i] = ring[i].Add(offset)
+			}
+		}
+	}
+
+	aNodes, aEdges := buildNodes(aRings)
+	bNodes, bEdges := buildNodes(bRings)
+	_, unmoved := buildNodes(original)
+	home := make(map[*clipNode]vector.Vector) // Where each node of b belongs before the nudge
+	for i, e := range bEdges {
+		home[e] = unmoved[i].point
+	}
+	splits := make(map[*clipNode][]*clipNode)
+	for _, ea := range aEdges {
+		for _, eb := range bEdges {
+			t, u, ok := crossing(ea.point, ea.next.point, eb.point, eb.next.point)
+			if !ok {
+				continue
+			}
+			p := ea.point.Add(ea.next.point.Sub(ea.point).Scale(t))
+			na := &clipNode{point: p, alpha: t, isCrossing: true}
+			nb := &clipNode{point: p, alpha: u, isCrossing: true}
+			na.neighbor, nb.neighbor = nb, na
+			splits[ea] = append(splits[ea], na)
+			splits[eb] = append(splits[eb], nb)
+			if drift > 0 {
+				home[na] = lineCrossing(ea.point, ea.next.point, home[eb], home[eb.next], p)
+				home[nb] = home[na]
+			}
+		}
+	}
+	aNodes = splice(aNodes, aEdges, splits)
+	bNodes = splice(bNodes, bEdges, splits)
+
+	classify(aNodes, bRings, keepAInside)
+	classify(bNodes, aRings, keepBInside)
+
+	if drift > 0 {
+		// Put b back and snap the crossings onto the corners they were
+		// nudged off, so shared edges and corners come out exact
+		for n, p := range home {
+			n.point = snap(p, 2*drift, aRings, original)
+		}
+	}
+
+	var rings [][]vector.Vector
+	scale := extent(aRings, original)
+	for _, start := range append(aNodes, bNodes...) {
+		if !start.keep || start.visited {
+			continue
+		}
+		var ring []vector.Vector
+		for cur := start; !cur.visited; {
+			cur.visited = true
+			ring = append(ring, cur.point)
+			cur = cur.next
+			if cur.isCrossing && cur.neighbor.visited {
+				cur.visited = true // Back where the ring crossed over, so it is closed
+				break
+			}
+			if cur.isCrossing && !cur.keep && cur.neighbor.keep {
+				cur = cur.neighbor // Carry on along the other shape
+			}
+		}
+		if ring = cleanRing(ring); ring != nil && !sliver(ring, scale) {
+			rings = append(rings, ring)
+		}
+	}
+	return groupRings(rings)
+}
+
+// ringsOf returns copies of the outlines wound counter-clockwise and the holes
+// wound clockwise, or the other way round when reversed.
+func ringsOf(regions []Region, reversed bool) [][]vector.Vector {
+	var rings [][]vector.Vector
+	for _, r := range regions {
+		for i, ring := range r.rings() {
+			if len(ring) < 3 || SignedArea(ring) == 0 {
+				continue
+			}
+			if (i == 0) != reversed {
+				ring = CounterClockwise(ring)
+			} else {
+				ring = Clockwise(ring)
+			}
+			rings = append(rings, append([]vector.Vector(nil), ring...))
+		}
+	}
+	return rings
+}
+
+// rings returns the outline of the region followed by its holes.
+func (r Region) rings() [][]vector.Vector {
+	return append([][]vector.Vector{r.Outline}, r.Holes...)
+}
+
+// insideRings reports whether point is inside an odd number of rings.
+func insideRings(rings [][]vector.Vector, point vector.Vector) bool {
+	inside := false
+	for _, ring := range rings {
+		for i := range ring {
+			a := ring[i]
+			b := ring[(i+1)%len(ring)]
+			if (a.Y > point.Y) != (b.Y > point.Y) {
+				x := a.X + (point.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+				if x > point.X {
+					inside = !inside
+				}
+			}
+		}
+	}
+	return inside
+}
+
+// crossingEpsilon is how close to an end of an edge, as a fraction of its
+// length, a crossing counts as touching that end.
+const crossingEpsilon = 1e-9
+
+// crossing returns where the segments a1-a2 and b1-b2 cross, as fractions t
+// along a and u along b. Segments that only touch or overlap do not cross.
+func crossing(a1, a2, b1, b2 vector.Vector) (float64, float64, bool) {
+	r := a2.Sub(a1)
+	s := b2.Sub(b1)
+	denom := r.Cross(s)
+	if math.Abs(denom) < 1e-12*r.Magnitude()*s.Magnitude() {
+		return 0, 0, false
+	}
+	d := b1.Sub(a1)
+	t := d.Cross(s) / denom
+	u := d.Cross(r) / denom
+	if t <= crossingEpsilon || t >= 1-crossingEpsilon || u <= crossingEpsilon || u >= 1-crossingEpsilon {
+		return 0, 0, false
+	}
+	return t, u, true
+}
+
+// lineCrossing returns where the lines through a1-a2 and b1-b2 cross, or
+// fallback if they are parallel.
+func lineCrossing(a1, a2, b1, b2, fallback vector.Vector) vector.Vector {
+	r := a2.Sub(a1)
+	s := b2.Sub(b1)
+	denom := r.Cross(s)
+	if math.Abs(denom) < 1e-12*r.Magnitude()*s.Magnitude() {
+		return fallback
+	}
+	return a1.Add(r.Scale(b1.Sub(a1).Cross(s) / denom))
+}
+
+// snap returns the vertex of the rings nearest to p if it is within
+// tolerance, and p otherwise.
+func snap(p vector.Vector, tolerance float64, sets ...[][]vector.Vector) vector.Vector {
+	best := p
+	for _, rings := range sets {
+		for _, ring := range rings {
+			for _, v := range ring {
+				if d := vector.Distance(p, v); d < tolerance {
+					best, tolerance = v, d
+				}
+			}
+		}
+	}
+	return best
+}
+
+// touching reports whether a vertex of one set of rings lies on an edge of the
+// other, which leaves crossings ambiguous.
+func touching(aRings, bRings [][]vector.Vector) bool {
+	scale := extent(aRings, bRings)
+	for _, ra := range aRings {
+		for i := range ra {
+			a1, a2 := ra[i], ra[(i+1)%len(ra)]
+			for _, rb := range bRings {
+				for k := range rb {
+					b1, b2 := rb[k], rb[(k+1)%len(rb)]
+					if segmentDistance(a1, a2, b1) < crossingEpsilon*scale ||
+						segmentDistance(b1, b2, a1) < crossingEpsilon*scale {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// extent returns the larger side of the box around all the rings.
+func extent(sets ...[][]vector.Vector) float64 {
+	lo := vector.Vector{X: math.Inf(1), Y: math.Inf(1)}
+	hi := vector.Vector{X: math.Inf(-1), Y: math.Inf(-1)}
+	for _, rings := range sets {
+		for _, ring := range rings {
+			for _, v := range ring {
+				lo = vector.Vector{X: math.Min(lo.X, v.X), Y: math.Min(lo.Y, v.Y)}
+				hi = vector.Vector{X: math.Max(hi.X, v.X), Y: math.Max(hi.Y, v.Y)}
+			}
+		}
+	}
+	return math.Max(hi.X-lo.X, hi.Y-lo.Y)
+}
+
+// buildNodes links each ring into a loop of nodes. It returns all the nodes
+// and, separately, the nodes that start an edge of the original rings.
+func buildNodes(rings [][]vector.Vector) ([]*clipNode, []*clipNode) {
+	var nodes []*clipNode
+	for _, ring := range rings {
+		first := len(nodes)
+		for _, v := range ring {
+			nodes = append(nodes, &clipNode{point: v})
+		}
+		for i := first; i < len(nodes); i++ {
+			next := i + 1
+			if next == len(nodes) {
+				next = first
+			}
+			nodes[i].next = nodes[next]
+		}
+	}
+	edges := append([]*clipNode(nil), nodes...)
+	return nodes, edges
+}
+
+// splice inserts the crossings found on each edge into its ring, in order
+// along the edge.
+func splice(nodes, edges []*clipNode, splits map[*clipNode][]*clipNode) []*clipNode {
+	for _, e := range edges {
+		crossings := splits[e]
+		sort.Slice(crossings, func(i, j int) bool { return crossings[i].alpha < crossings[j].alpha })
+		end := e.next
+		prev := e
+		for _, c := range crossings {
+			prev.next = c
+			prev = c
+			nodes = append(nodes, c)
+		}
+		prev.next = end
+	}
+	return nodes
+}
+
+// classify marks the edges leaving each node as kept when their midpoints are
+// inside the other shape, or outside it when keepInside is false.
+func classify(nodes []*clipNode, other [][]vector.Vector, keepInside bool) {
+	for _, n := range nodes {
+		mid := n.point.Add(n.next.point).Scale(0.5)
+		n.keep = insideRings(other, mid) == keepInside
+	}
+}
+
+// cleanRing drops repeated and collinear vertices from a traced ring, and
+// returns nil if nothing with area is left.
+func cleanRing(ring []vector.Vector) []vector.Vector {
+	for changed := true; changed && len(ring) >= 3; {
+		changed = false
+		for i := 0; i < len(ring); i++ {
+			prev := ring[(i-1+len(ring))%len(ring)]
+			next := ring[(i+1)%len(ring)]
+			flat := math.Abs(turn(prev, ring[i], next)) <= 1e-12*vector.Distance(prev, ring[i])*vector.Distance(ring[i], next)
+			if ring[i] == next || flat {
+				ring = append(ring[:i], ring[i+1:]...)
+				changed = true
+				break
+			}
+		}
+	}
+	if len(ring) < 3 || Area(ring) < 1e-9 {
+		return nil
+	}
+	return ring
+}
+
+// sliver reports whether a ring is so thin that it is only left over from
+// nudging edges that lay on top of each other apart.
+func sliver(ring []vector.Vector, scale float64) bool {
+	perimeter := 0.0
+	for i := range ring {
+		perimeter += vector.Distance(ring[i], ring[(i+1)%len(ring)])
+	}
+	return 2*Area(ring)/perimeter < 1e-4*scale
+}
+
+// groupRings sorts rings into regions: counter-clockwise rings are outlines,
+// and each clockwise ring becomes a hole of the smallest outline around it.
+func groupRings(rings [][]vector.Vector) []Region {
+	var regions []Region
+	var holes [][]vector.Vector
+	for _, ring := range rings {
+		if SignedArea(ring) > 0 {
+			regions = append(regions, Region{Outline: ring})
+		} else {
+			holes = append(holes, ring)
+		}
+	}
+	for _, hole := range holes {
+		best := -1
+		for i, r := range regions {
+			if !Contains(r.Outline, beside(hole)) {
+				continue
+			}
+			if best < 0 || Area(r.Outline) < Area(regions[best].Outline) {
+				best = i
+			}
+		}
+		if best >= 0 {
+			regions[best].Holes = append(regions[best].Holes, hole)
+		}
+	}
+	return regions
+}
+
+// beside returns a point just off the first edge of a clockwise hole, on the
+// side away from the hole.
+func beside(hole []vector.Vector) vector.Vector {
+	edge := hole[1].Sub(hole[0])
+	mid := hole[0].Add(edge.Scale(0.5))
+	return mid.Add(vector.Vector{X: -edge.Y, Y: edge.X}.Scale(1e-6))
+}
+
+// Pieces splits the region into simple outlines without holes, cutting each
+// hole open with a vertical line through it. Regions without holes are
+// returned as their outline.
+func (r Region) Pieces() [][]vector.Vector {
+	if len(r.Holes) == 0 {
+		return [][]vector.Vector{r.Outline}
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range r.Holes[0] {
+		lo, hi = math.Min(lo, v.X), math.Max(hi, v.X)
+	}
+	x := (lo + hi) / 2
+	top, bottom := math.Inf(1), math.Inf(-1)
+	left, right := math.Inf(1), math.Inf(-1)
+	for _, v := range r.Outline {
+		top, bottom = math.Min(top, v.Y), math.Max(bottom, v.Y)
+		left, right = math.Min(left, v.X), math.Max(right, v.X)
+	}
+	top, bottom = top-1, bottom+1
+	halves := []Region{
+		{Outline: []vector.Vector{{X: left - 1, Y: top}, {X: x, Y: top}, {X: x, Y: bottom}, {X: left - 1, Y: bottom}}},
+		{Outline: []vector.Vector{{X: x, Y: top}, {X: right + 1, Y: top}, {X: right + 1, Y: bottom}, {X: x, Y: bottom}}},
+	}
+	var pieces [][]vector.Vector
+	for _, half := range halves {
+		for _, part := range Intersection([]Region{r}, []Region{half}) {
+			if len(part.Holes) >= len(r.Holes) {
+				// The cut missed; keep the outline rather than cut forever
+				pieces = append(pieces, part.Outline)
+				continue
+			}
+			pieces = append(pieces, part.Pieces()...)
+		}
+	}
+	return pieces
+}
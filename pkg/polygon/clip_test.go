@@ -0,0 +1,61 @@
+package polygon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+func square(x, y, size float64) []Region {
+	return []Region{{Outline: []vector.Vector{
+		{X: x, Y: y}, {X: x + size, Y: y}, {X: x + size, Y: y + size}, {X: x, Y: y + size},
+	}}}
+}
+
+func TestClipDegenerate(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func(a, b []Region) []Region
+		a, b     []Region
+		regions  int
+		vertices int // Of the first outline, 0 to skip
+		holes    int
+		area     float64
+	}{
+		{"union of squares sharing an edge", Union, square(0, 0, 1), square(1, 0, 1), 1, 4, 0, 2},
+		{"union of a square with itself", Union, square(0, 0, 1), square(0, 0, 1), 1, 4, 0, 1},
+		{"intersection of a square with itself", Intersection, square(0, 0, 1), square(0, 0, 1), 1, 4, 0, 1},
+		{"difference of a square with itself", Difference, square(0, 0, 1), square(0, 0, 1), 0, 0, 0, 0},
+		{"intersection of squares sharing an edge", Intersection, square(0, 0, 1), square(1, 0, 1), 0, 0, 0, 0},
+		{"difference of squares sharing an edge", Difference, square(0, 0, 1), square(1, 0, 1), 1, 4, 0, 1},
+		{"xor of squares sharing an edge", Xor, square(0, 0, 1), square(1, 0, 1), 2, 4, 0, 2},
+		{"union sharing part of an edge", Union, square(0, 0, 2), square(2, 1, 1), 1, 6, 0, 5},
+		{"union sharing a corner", Union, square(0, 0, 2), square(1, 1, 2), 1, 8, 0, 7},
+		{"difference sharing an edge inside", Difference, square(0, 0, 2), square(0, 0, 1), 1, 6, 0, 3},
+		{"difference cutting a hole", Difference, square(0, 0, 3), square(1, 1, 1), 1, 4, 1, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.op(tt.a, tt.b)
+			if len(got) != tt.regions {
+				t.Fatalf("got %d regions, want %d: %v", len(got), tt.regions, got)
+			}
+			area := 0.0
+			holes := 0
+			for _, r := range got {
+				area += r.Area()
+				holes += len(r.Holes)
+			}
+			if math.Abs(area-tt.area) > 1e-12 {
+				t.Errorf("area = %v, want %v", area, tt.area)
+			}
+			if holes != tt.holes {
+				t.Errorf("got %d holes, want %d", holes, tt.holes)
+			}
+			if tt.vertices > 0 && len(got[0].Outline) != tt.vertices {
+				t.Errorf("outline has %d vertices, want %d: %v", len(got[0].Outline), tt.vertices, got[0].Outline)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package polygon
+
+import (
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Compound is a single rigid body made of convex polygon fixtures, used for
+// concave outlines that the separating axis test cannot handle directly.
+type Compound struct {
+	rigidbody.RigidBody
+}
+
+// NewCompound creates a compound body from a simple world-space outline,
+// convex or not. The outline is split into convex fixtures with Decompose,
+// and the body is placed at the centroid of the outline with an angle of 0.
+func NewCompound(vertices []vector.Vector, density float64, IsMovable bool) *Compound {
+	return newCompound([][]vector.Vector{vertices}, density, IsMovable)
+}
+
+// NewRegionCompound creates a compound body from a region, such as a result
+// of Difference, holes and all. The body is placed at the centroid of the
+// region with an angle of 0.
+func NewRegionCompound(r Region, density float64, IsMovable bool) *Compound {
+	return newCompound(r.Pieces(), density, IsMovable)
+}
+
+// newCompound creates a compound body from simple world-space outlines.
+func newCompound(outlines [][]vector.Vector, density float64, IsMovable bool) *Compound {
+	// Measure the fixtures from the first outline's centroid to limit rounding;
+	// ResetMassData then moves the body to the centroid of them all
+	origin := CalculateCentroid(outlines[0])
+	c := &Compound{
+		RigidBody: rigidbody.RigidBody{
+			Position:  origin,
+			Shape:     "compound",
+			IsMovable: IsMovable,
+		},
+	}
+	for _, outline := range outlines {
+		local := make([]vector.Vector, len(outline))
+		for i, v := range outline {
+			local[i] = v.Sub(origin)
+		}
+		c.Fixtures = append(c.Fixtures, NewFixtures(local, density)...)
+	}
+	c.ResetMassData()
+	return c
+}
+
+// Decompose turns the polygon into a compound body with the same placement,
+// velocity and mass.
+func (p *Polygon) Decompose() *Compound {
+	c := &Compound{RigidBody: p.RigidBody}
+	c.Shape = "compound"
+	c.Fixtures = NewFixtures(p.Vertices, 1)
+	c.SetMass(p.Mass)
+	return c
+}
+
+// WorldPieces returns the outlines of the polygon fixtures in world space.
+func (c *Compound) WorldPieces() [][]vector.Vector {
+	var pieces [][]vector.Vector
+	for _, f := range c.Fixtures {
+		if f.Shape != "Polygon" {
+			continue
+		}
+		t := f.Transform(&c.RigidBody)
+		piece := make([]vector.Vector, len(f.Vertices))
+		for k, v := range f.Vertices {
+			piece[k] = t.Apply(v)
+		}
+		pieces = append(pieces, piece)
+	}
+	return pieces
+}
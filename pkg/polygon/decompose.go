@@ -0,0 +1,164 @@
+package polygon
+
+import (
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Triangulate splits a simple polygon, convex or not, into triangles by ear
+// clipping. The triangles wind counter-clockwise in a y-up frame.
+func Triangulate(vertices []vector.Vector) [][]vector.Vector {
+	vertices = CounterClockwise(vertices)
+	var triangles [][]vector.Vector
+	for _, t := range triangulate(vertices) {
+		triangles = append(triangles, []vector.Vector{vertices[t[0]], vertices[t[1]], vertices[t[2]]})
+	}
+	return triangles
+}
+
+// Decompose splits a simple polygon into convex pieces. It triangulates the
+// polygon and then removes every diagonal whose two sides still form a convex
+// piece together (Hertel-Mehlhorn), which gives at most four times the
+// fewest possible pieces. The pieces wind counter-clockwise in a y-up frame.
+func Decompose(vertices []vector.Vector) [][]vector.Vector {
+	vertices = CounterClockwise(vertices)
+	var pieces [][]int
+	for _, t := range triangulate(vertices) {
+		pieces = append(pieces, []int{t[0], t[1], t[2]})
+	}
+
+	for merging := true; merging; {
+		merging = false
+		for i := 0; i < len(pieces) && !merging; i++ {
+			for k := 0; k < len(pieces[i]) && !merging; k++ {
+				a := pieces[i][k]
+				b := pieces[i][(k+1)%len(pieces[i])]
+				j := pieceWithEdge(pieces, b, a)
+				if j < 0 {
+					continue // Outline edge, not a diagonal
+				}
+				merged := mergePieces(pieces[i], pieces[j], a, b)
+				if !isConvex(vertices, merged) {
+					continue
+				}
+				pieces[i] = merged
+				pieces = append(pieces[:j], pieces[j+1:]...)
+				merging = true
+			}
+		}
+	}
+
+	out := make([][]vector.Vector, len(pieces))
+	for i, p := range pieces {
+		out[i] = make([]vector.Vector, len(p))
+		for k, v := range p {
+			out[i][k] = vertices[v]
+		}
+	}
+	return out
+}
+
+// triangulate clips ears off a counter-clockwise polygon and returns the
+// triangles as vertex indices.
+func triangulate(vertices []vector.Vector) [][3]int {
+	remaining := make([]int, len(vertices))
+	for i := range remaining {
+		remaining[i] = i
+	}
+	var triangles [][3]int
+	for len(remaining) > 3 {
+		clipped := false
+		for k := range remaining {
+			prev := remaining[(k-1+len(remaining))%len(remaining)]
+			cur := remaining[k]
+			next := remaining[(k+1)%len(remaining)]
+			if !isEar(vertices, remaining, prev, cur, next) {
+				continue
+			}
+			triangles = append(triangles, [3]int{prev, cur, next})
+			remaining = append(remaining[:k], remaining[k+1:]...)
+			clipped = true
+			break
+		}
+		if !clipped {
+			// Only collinear or overlapping vertices are left; drop one
+			// without making a triangle
+			remaining = remaining[1:]
+		}
+	}
+	if len(remaining) == 3 && turn(vertices[remaining[0]], vertices[remaining[1]], vertices[remaining[2]]) > 0 {
+		triangles = append(triangles, [3]int{remaining[0], remaining[1], remaining[2]})
+	}
+	return triangles
+}
+
+// isEar reports whether the corner prev-cur-next is convex and holds no other
+// vertex of the polygon.
+func isEar(vertices []vector.Vector, remaining []int, prev, cur, next int) bool {
+	a, b, c := vertices[prev], vertices[cur], vertices[next]
+	if turn(a, b, c) <= 0 {
+		return false
+	}
+	for _, i := range remaining {
+		if i == prev || i == cur || i == next {
+			continue
+		}
+		p := vertices[i]
+		if turn(a, b, p) >= 0 && turn(b, c, p) >= 0 && turn(c, a, p) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// turn is positive when a-b-c turns counter-clockwise in a y-up frame.
+func turn(a, b, c vector.Vector) float64 {
+	return b.Sub(a).Cross(c.Sub(b))
+}
+
+// pieceWithEdge returns the index of the piece holding the directed edge a-b.
+func pieceWithEdge(pieces [][]int, a, b int) int {
+	for i, p := range pieces {
+		for k := range p {
+			if p[k] == a && p[(k+1)%len(p)] == b {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// mergePieces joins piece p, which holds the edge a-b, with piece q, which
+// holds the edge b-a, across that edge.
+func mergePieces(p, q []int, a, b int) []int {
+	var merged []int
+	start := indexOf(p, b)
+	for k := 0; k < len(p); k++ {
+		merged = append(merged, p[(start+k)%len(p)]) // b, ..., a
+	}
+	start = indexOf(q, a)
+	for k := 1; k < len(q)-1; k++ {
+		merged = append(merged, q[(start+k)%len(q)]) // Skip a and b
+	}
+	return merged
+}
+
+func indexOf(s []int, v int) int {
+	for i, x := range s {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
+
+func isConvex(vertices []vector.Vector, piece []int) bool {
+	for k := range piece {
+		a := vertices[piece[k]]
+		b := vertices[piece[(k+1)%len(piece)]]
+		c := vertices[piece[(k+2)%len(piece)]]
+		if turn(a, b, c) < 0 {
+			return false
+		}
+	}
+	return true
+}
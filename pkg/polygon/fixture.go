@@ -0,0 +1,35 @@
+package polygon
+
+import (
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// NewPolygonFixture creates a convex polygon fixture from an outline in body
+// space. The fixture is placed at the centroid of the outline.
+func NewPolygonFixture(vertices []vector.Vector, density float64) *rigidbody.Fixture {
+	centroid := CalculateCentroid(vertices)
+	local := make([]vector.Vector, len(vertices))
+	for i, v := range vertices {
+		local[i] = v.Sub(centroid)
+	}
+	area := Area(local)
+	return &rigidbody.Fixture{
+		Shape:       "Polygon",
+		Offset:      centroid,
+		Vertices:    local,
+		Density:     density,
+		Area:        area,
+		UnitInertia: CalculateInertia(local, area),
+	}
+}
+
+// NewFixtures splits an outline in body space, convex or not, into convex
+// polygon fixtures.
+func NewFixtures(vertices []vector.Vector, density float64) []*rigidbody.Fixture {
+	var fixtures []*rigidbody.Fixture
+	for _, piece := range Decompose(vertices) {
+		fixtures = append(fixtures, NewPolygonFixture(piece, density))
+	}
+	return fixtures
+}
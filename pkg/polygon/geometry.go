@@ -0,0 +1,231 @@
+package polygon
+
+import (
+	"math"
+	"sort"
+
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// IsCounterClockwise reports whether the vertices wind counter-clockwise in a
+// y-up frame. On screen, where y points down, such an outline looks clockwise.
+func IsCounterClockwise(vertices []vector.Vector) bool {
+	return SignedArea(vertices) > 0
+}
+
+// CounterClockwise returns the vertices wound counter-clockwise in a y-up
+// frame. They are returned as they are when already in that order and as a
+// reversed copy otherwise.
+func CounterClockwise(vertices []vector.Vector) []vector.Vector {
+	if SignedArea(vertices) >= 0 {
+		return vertices
+	}
+	return reverse(vertices)
+}
+
+// Clockwise returns the vertices wound clockwise in a y-up frame. They are
+// returned as they are when already in that order and as a reversed copy
+// otherwise.
+func Clockwise(vertices []vector.Vector) []vector.Vector {
+	if SignedArea(vertices) <= 0 {
+		return vertices
+	}
+	return reverse(vertices)
+}
+
+func reverse(vertices []vector.Vector) []vector.Vector {
+	reversed := make([]vector.Vector, len(vertices))
+	for i, v := range vertices {
+		reversed[len(vertices)-1-i] = v
+	}
+	return reversed
+}
+
+// IsConvex reports whether a polygon in either winding is convex. Collinear
+// vertices are allowed; outlines that cross themselves are not convex.
+func IsConvex(vertices []vector.Vector) bool {
+	if len(vertices) < 3 {
+		return false
+	}
+	sign := 0.0
+	turned := 0.0
+	for i := range vertices {
+		a := vertices[i]
+		b := vertices[(i+1)%len(vertices)]
+		c := vertices[(i+2)%len(vertices)]
+		t := turn(a, b, c)
+		if t == 0 {
+			continue
+		}
+		if sign == 0 {
+			sign = math.Copysign(1, t)
+		} else if sign*t < 0 {
+			return false
+		}
+		turned += math.Atan2(t, b.Sub(a).InnerProduct(c.Sub(b)))
+	}
+	// A simple convex outline turns exactly once; a star turns more
+	return sign != 0 && math.Abs(turned) < 3*math.Pi
+}
+
+// Contains reports whether point lies inside a simple polygon in either
+// winding. Points on the outline count as inside.
+func Contains(vertices []vector.Vector, point vector.Vector) bool {
+	inside := false
+	for i := range vertices {
+		a := vertices[i]
+		b := vertices[(i+1)%len(vertices)]
+		if onSegment(a, b, point) {
+			return true
+		}
+		// Count crossings of a ray from point towards +X
+		if (a.Y > point.Y) != (b.Y > point.Y) {
+			x := a.X + (point.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+			if x > point.X {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// onSegment reports whether p lies on the segment a-b, within rounding.
+func onSegment(a, b, p vector.Vector) bool {
+	ab := b.Sub(a)
+	ap := p.Sub(a)
+	length := ab.InnerProduct(ab)
+	if length == 0 {
+		return ap.InnerProduct(ap) < 1e-18
+	}
+	if math.Abs(ab.Cross(ap)) > 1e-9*length {
+		return false
+	}
+	t := ap.InnerProduct(ab)
+	return t >= 0 && t <= length
+}
+
+// ConvexHull returns the smallest convex polygon holding all the points,
+// found with Andrew's monotone chain. The hull winds counter-clockwise in a
+// y-up frame and leaves out collinear points. Fewer than three distinct points
+// are returned as they are.
+func ConvexHull(points []vector.Vector) []vector.Vector {
+	sorted := make([]vector.Vector, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+	unique := sorted[:0]
+	for i, p := range sorted {
+		if i == 0 || p != sorted[i-1] {
+			unique = append(unique, p)
+		}
+	}
+	if len(unique) < 3 {
+		return unique
+	}
+
+	hull := make([]vector.Vector, 0, 2*len(unique))
+	for _, p := range unique { // Lower hull
+		for len(hull) >= 2 && turn(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(unique) - 2; i >= 0; i-- { // Upper hull
+		p := unique[i]
+		for len(hull) >= lower && turn(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	return hull[:len(hull)-1] // The last point repeats the first
+}
+
+// Simplify removes vertices from a closed outline that lie within tolerance
+// of the simplified outline, using Ramer-Douglas-Peucker. The winding and the
+// order of the kept vertices are unchanged, and at least three are kept. An
+// outline whose vertices are all the same point is returned unchanged.
+func Simplify(vertices []vector.Vector, tolerance float64) []vector.Vector {
+	if len(vertices) <= 3 {
+		return vertices
+	}
+	// Split the loop at the first vertex and the one farthest from it, and
+	// simplify both halves as open lines
+	far := 0
+	for i, v := range vertices {
+		if vector.Distance(v, vertices[0]) > vector.Distance(vertices[far], vertices[0]) {
+			far = i
+		}
+	}
+	if far == 0 {
+		return vertices // Every vertex is the same point
+	}
+	keep := make([]bool, len(vertices))
+	keep[0], keep[far] = true, true
+	simplifyLine(vertices, 0, far, tolerance, keep)
+	closed := append(vertices[far:len(vertices):len(vertices)], vertices[0])
+	tail := make([]bool, len(closed))
+	simplifyLine(closed, 0, len(closed)-1, tolerance, tail)
+	for i := 1; i < len(closed)-1; i++ {
+		keep[far+i] = tail[i]
+	}
+
+	var out []vector.Vector
+	for i, v := range vertices {
+		if keep[i] {
+			out = append(out, v)
+		}
+	}
+	if len(out) < 3 {
+		// Keep the vertex farthest from the line between the two left
+		best, bestDistance := -1, -1.0
+		for i, v := range vertices {
+			if d := segmentDistance(vertices[0], vertices[far], v); !keep[i] && d > bestDistance {
+				best, bestDistance = i, d
+			}
+		}
+		keep[best] = true
+		out = out[:0]
+		for i, v := range vertices {
+			if keep[i] {
+				out = append(out, v)
+			}
+		}
+	}
+	return out
+}
+
+// simplifyLine marks in keep the vertices between first and last that the
+// Ramer-Douglas-Peucker simplification of that stretch keeps.
+func simplifyLine(vertices []vector.Vector, first, last int, tolerance float64, keep []bool) {
+	if last-first < 2 {
+		return
+	}
+	index, distance := -1, tolerance
+	for i := first + 1; i < last; i++ {
+		if d := segmentDistance(vertices[first], vertices[last], vertices[i]); d > distance {
+			index, distance = i, d
+		}
+	}
+	if index < 0 {
+		return
+	}
+	keep[index] = true
+	simplifyLine(vertices, first, index, tolerance, keep)
+	simplifyLine(vertices, index, last, tolerance, keep)
+}
+
+// segmentDistance returns the distance from p to the segment a-b.
+func segmentDistance(a, b, p vector.Vector) float64 {
+	ab := b.Sub(a)
+	closest := a
+	if length := ab.InnerProduct(ab); length > 0 {
+		t := math.Max(0, math.Min(1, p.Sub(a).InnerProduct(ab)/length))
+		closest = a.Add(ab.Scale(t))
+	}
+	return vector.Distance(p, closest)
+}
@@ -0,0 +1,60 @@
+package polygon
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// SignedArea returns the area of a simple polygon, positive when the vertices
+// wind counter-clockwise in a y-up frame and negative otherwise.
+func SignedArea(vertices []vector.Vector) float64 {
+	area := 0.0
+	for i := range vertices {
+		area += vertices[i].Cross(vertices[(i+1)%len(vertices)])
+	}
+	return area / 2
+}
+
+// Area returns the area of a simple polygon in either winding.
+func Area(vertices []vector.Vector) float64 {
+	return math.Abs(SignedArea(vertices))
+}
+
+// CalculateInertia returns the moment of inertia about the centroid of a
+// simple polygon of the given mass, convex or not.
+func CalculateInertia(vertices []vector.Vector, mass float64) float64 {
+	area := SignedArea(vertices)
+	if area == 0 || mass <= 0 {
+		return 0
+	}
+	c := CalculateCentroid(vertices)
+	sum := 0.0
+	for i := range vertices {
+		a := vertices[i].Sub(c)
+		b := vertices[(i+1)%len(vertices)].Sub(c)
+		sum += a.Cross(b) * (a.InnerProduct(a) + a.InnerProduct(b) + b.InnerProduct(b))
+	}
+	// sum/12 is the inertia of the polygon at unit density
+	return mass * sum / (12 * area)
+}
+
+// SetMass sets the mass of the polygon along with its inverse mass, moment of
+// inertia and inverse inertia.
+func (p *Polygon) SetMass(mass float64) {
+	p.Mass = mass
+	p.Inertia = CalculateInertia(p.Vertices, mass)
+	p.InvMass = 0
+	p.InvInertia = 0
+	if mass > 0 {
+		p.InvMass = 1 / mass
+	}
+	if p.Inertia > 0 {
+		p.InvInertia = 1 / p.Inertia
+	}
+}
+
+// SetDensity computes the mass of the polygon from its area and density.
+func (p *Polygon) SetDensity(density float64) {
+	p.SetMass(density * Area(p.Vertices))
+}
@@ -9,33 +9,49 @@ import (
 //Polygon 2d
 type Polygon struct {
 	rigidbody.RigidBody
-	Vertices []vector.Vector
-    Rotation float64 // Added rotation property
-    Torque   float64 // Added torque property
+	Vertices []vector.Vector // Body-space outline about the centroid; see WorldVertices
 
+	world   []vector.Vector     // World-space vertices for worldAt
+	worldAt rigidbody.Transform // Transform the cached world vertices were built for
+	cached  bool
 }
 
-// NewPolygon creates a new polygon with given properties.
-func NewPolygon(vertices []vector.Vector, mass float64, IsMovable bool) *Polygon {
+// NewPolygon creates a new polygon from its world-space outline. The polygon
+// is placed at the centroid of the outline with an angle of 0, and its mass and
+// moment of inertia are computed from the area and density.
+func NewPolygon(vertices []vector.Vector, density float64, IsMovable bool) *Polygon {
+	centroid := CalculateCentroid(vertices)
 	polygon := &Polygon{
 		RigidBody: rigidbody.RigidBody{
-			Position:  CalculateCentroid(vertices),
+			Position:  centroid,
 			Velocity:  vector.Vector{X: 0, Y: 0},
 			Force:     vector.Vector{X: 0, Y: 0},
-			Mass:      mass,
 			Shape:     "polygon",
 			IsMovable: IsMovable,
 			Restitution : 1.0,
 		},
-        Rotation: 0, // Initial rotation is set to 0
-        Torque:   0, // Initial torque is set to 0
-		Vertices: vertices,
 	}
+	polygon.Vertices = make([]vector.Vector, len(vertices))
+	for i, v := range vertices {
+		polygon.Vertices[i] = v.Sub(centroid)
+	}
+	polygon.SetDensity(density)
 	return polygon
 }
 
-// CalculateCentroid calculates the centroid of a polygon given its vertices.
+// CalculateCentroid calculates the centre of area of a simple polygon given its
+// vertices. Degenerate polygons with no area fall back to the vertex average.
 func CalculateCentroid(vertices []vector.Vector) vector.Vector {
+	if area := SignedArea(vertices); area != 0 {
+		var c vector.Vector
+		origin := vertices[0] // Measured from the first vertex to limit rounding
+		for i := range vertices {
+			a := vertices[i].Sub(origin)
+			b := vertices[(i+1)%len(vertices)].Sub(origin)
+			c = c.Add(a.Add(b).Scale(a.Cross(b)))
+		}
+		return origin.Add(c.Scale(1 / (6 * area)))
+	}
 	var centroid vector.Vector
 	for _, v := range vertices {
 		centroid.X += v.X
@@ -46,29 +62,60 @@ func CalculateCentroid(vertices []vector.Vector) vector.Vector {
 	return centroid
 }
 
-//Update position of polygon
-func (p *Polygon) UpdatePosition(){
-	p.Position = CalculateCentroid(p.Vertices)
-    p.Rotation = p.Torque / p.Mass // Update rotation based on torque and mass
+// WorldVertices returns the outline in world space for the current Position
+// and Angle. The result is cached until the polygon moves or turns, and must
+// not be modified.
+func (p *Polygon) WorldVertices() []vector.Vector {
+	t := p.Transform()
+	if p.cached && t == p.worldAt && len(p.world) == len(p.Vertices) {
+		return p.world
+	}
+	if cap(p.world) < len(p.Vertices) {
+		p.world = make([]vector.Vector, len(p.Vertices))
+	}
+	p.world = p.world[:len(p.Vertices)]
+	for i, v := range p.Vertices {
+		p.world[i] = t.Apply(v)
+	}
+	p.worldAt, p.cached = t, true
+	return p.world
 }
 
+// SetVertices replaces the body-space outline and recomputes the mass
+// properties with the current density.
+func (p *Polygon) SetVertices(vertices []vector.Vector) {
+	density := 0.0
+	if area := Area(p.Vertices); area > 0 {
+		density = p.Mass / area
+	}
+	p.Vertices = vertices
+	p.cached = false
+	p.SetDensity(density)
+}
 
+// Deprecated: Position and Angle place the polygon and its world vertices
+// follow them, so there is nothing to update.
+func (p *Polygon) UpdatePosition() {}
 
 // IMPART Impulse on a body
 func (rb *Polygon) ApplyImpulse(impulse vector.Vector) {
+    if !rb.IsDynamic() {
+        return
+    }
+    rb.WakeUp()
+
     // Calculate the change in velocity using impulse and mass
-    change_velocity := impulse.Scale(1/rb.Mass);
+    change_velocity := impulse.Scale(rb.InverseMass());
     rb.Velocity = rb.Velocity.Add(change_velocity)
-
-    rb.Rotation += rb.Torque / rb.Mass // Update rotation based on torque and mass
 }
 
 // Project calculates the projection of a polygon onto a given axis.
 func Project(p Polygon, axis vector.Vector) (float64, float64) {
-    min := axis.InnerProduct(p.Vertices[0])
+    vertices := p.WorldVertices()
+    min := axis.InnerProduct(vertices[0])
     max := min
-    for i := 1; i < len(p.Vertices); i++ {
-        d := axis.InnerProduct(p.Vertices[i])
+    for i := 1; i < len(vertices); i++ {
+        d := axis.InnerProduct(vertices[i])
         if d < min {
             min = d
         } else if d > max {
@@ -80,29 +127,37 @@ func Project(p Polygon, axis vector.Vector) (float64, float64) {
 
 // Move adjusts the position of the polygon by the given displacement vector.
 func (p *Polygon) Move(displacement vector.Vector) {
-    for i := range p.Vertices {
-        p.Vertices[i] = p.Vertices[i].Add(displacement)
-    }
+    p.Position = p.Position.Add(displacement)
 }
 
+// Rotate turns the polygon about its centroid by angle radians.
+func (p *Polygon) Rotate(angle float64) {
+    p.Angle += angle
+}
 
-// ClosestPoint finds the closest point on the polygon to a given point (x, y).
+// ClosestPoint finds the closest point on the outline of the polygon to a given point (x, y).
 func (poly *Polygon) ClosestPoint(x, y float64) (float64, float64) {
     minDistanceSquared := math.MaxFloat64
     closestX, closestY := 0.0, 0.0
 
-    for i := 0; i < len(poly.Vertices); i++ {
-        vertexX := poly.Vertices[i].X + poly.Position.X
-        vertexY := poly.Vertices[i].Y + poly.Position.Y
-        dx := x - vertexX
-        dy := y - vertexY
+    point := vector.Vector{X: x, Y: y}
+    vertices := poly.WorldVertices()
+    for i := range vertices {
+        a, b := vertices[i], vertices[(i+1)%len(vertices)]
+        closest := a
+        if ab := b.Sub(a); ab.InnerProduct(ab) > 0 {
+            t := math.Max(0, math.Min(1, point.Sub(a).InnerProduct(ab)/ab.InnerProduct(ab)))
+            closest = a.Add(ab.Scale(t))
+        }
+        dx := x - closest.X
+        dy := y - closest.Y
         distanceSquared := dx*dx + dy*dy
         if distanceSquared < minDistanceSquared {
             minDistanceSquared = distanceSquared
-            closestX = vertexX
-            closestY = vertexY
+            closestX = closest.X
+            closestY = closest.Y
         }
     }
 
     return closestX, closestY
-}
\ No newline at end of file
+}
@@ -0,0 +1,91 @@
+package polygon
+
+import (
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Slice cuts a convex polygon in two along the segment from a to b, for
+// slicing and cutting mechanics. The cut must pass right through the polygon,
+// with a and b outside it; otherwise Slice returns nil, nil and the polygon
+// is left alone. Concave polygons are not cut either, since a cut could leave
+// pieces in several parts; slice the pieces of Decompose instead.
+//
+// The two pieces keep the polygon's density, type, material and angle, and
+// move as the part of the polygon they came from did: each takes the
+// polygon's angular velocity and the velocity of its own centroid. The
+// original polygon should be removed from the world in favour of the pieces.
+func Slice(p *Polygon, a, b vector.Vector) (*Polygon, *Polygon) {
+	if !IsConvex(p.Vertices) {
+		return nil, nil
+	}
+	// Split in body space so the pieces keep the polygon's angle
+	left, right, ok := split(p.Vertices, p.LocalPoint(a), p.LocalPoint(b))
+	if !ok || len(left) < 3 || len(right) < 3 {
+		return nil, nil
+	}
+	density := 0.0
+	if area := Area(p.Vertices); area > 0 {
+		density = p.Mass / area
+	}
+	return NewPiece(&p.RigidBody, left, density), NewPiece(&p.RigidBody, right, density)
+}
+
+// NewPiece creates a polygon from part of a body, such as a shard or a slice,
+// given its outline in the body's space. The piece keeps the body's type,
+// material and angle, takes the body's angular velocity and the velocity of
+// its own centroid, and gets its mass from density.
+func NewPiece(rb *rigidbody.RigidBody, local []vector.Vector, density float64) *Polygon {
+	centroid := CalculateCentroid(local)
+	piece := &Polygon{RigidBody: *rb}
+	piece.Shape = "polygon"
+	piece.Width, piece.Height, piece.Radius = 0, 0, 0
+	piece.Fixtures = nil
+	piece.Vertices = make([]vector.Vector, len(local))
+	for i, v := range local {
+		piece.Vertices[i] = v.Sub(centroid)
+	}
+	piece.Position = rb.WorldPoint(centroid)
+	piece.Velocity = rb.VelocityAt(piece.Position)
+	piece.SetDensity(density)
+	piece.WakeUp()
+	return piece
+}
+
+// split cuts a convex outline in two where the segment from a to b crosses
+// it, and returns the parts to the left and right of the segment in a y-up
+// frame. ok is false unless the segment crosses the outline at exactly two
+// points between its ends.
+func split(vertices []vector.Vector, a, b vector.Vector) (left, right []vector.Vector, ok bool) {
+	ab := b.Sub(a)
+	cuts := 0
+	for i := range vertices {
+		cur := vertices[i]
+		next := vertices[(i+1)%len(vertices)]
+		dc := turn(a, b, cur)
+		dn := turn(a, b, next)
+		if dc >= 0 {
+			left = append(left, cur)
+		}
+		if dc <= 0 {
+			right = append(right, cur)
+		}
+		var cut vector.Vector
+		switch {
+		case dc == 0:
+			cut = cur
+		case (dc > 0 && dn < 0) || (dc < 0 && dn > 0):
+			cut = cur.Add(next.Sub(cur).Scale(dc / (dc - dn)))
+			left = append(left, cut)
+			right = append(right, cut)
+		default:
+			continue
+		}
+		// Only cut where the segment is, not on the line beyond its ends
+		if t := cut.Sub(a).InnerProduct(ab) / ab.InnerProduct(ab); t <= 0 || t >= 1 {
+			return nil, nil, false
+		}
+		cuts++
+	}
+	return left, right, cuts == 2
+}
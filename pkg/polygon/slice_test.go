@@ -0,0 +1,73 @@
+package polygon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+func TestSlice(t *testing.T) {
+	box := []vector.Vector{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}}
+	u := []vector.Vector{
+		{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 3, Y: 3}, {X: 2, Y: 3},
+		{X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 3}, {X: 0, Y: 3},
+	}
+	tests := []struct {
+		name    string
+		outline []vector.Vector
+		a, b    vector.Vector
+		cut     bool
+		areas   [2]float64 // Of the pieces, smaller first
+	}{
+		{"box across the middle", box, vector.Vector{X: -1, Y: 1}, vector.Vector{X: 3, Y: 1}, true, [2]float64{2, 2}},
+		{"box corner to corner", box, vector.Vector{X: -1, Y: -1}, vector.Vector{X: 3, Y: 3}, true, [2]float64{2, 2}},
+		{"box off centre", box, vector.Vector{X: 0.5, Y: -1}, vector.Vector{X: 0.5, Y: 3}, true, [2]float64{1, 3}},
+		{"box ending inside", box, vector.Vector{X: -1, Y: 1}, vector.Vector{X: 1, Y: 1}, false, [2]float64{}},
+		{"box starting inside", box, vector.Vector{X: 1, Y: 1}, vector.Vector{X: 3, Y: 1}, false, [2]float64{}},
+		{"box missed", box, vector.Vector{X: -1, Y: 3}, vector.Vector{X: 3, Y: 3}, false, [2]float64{}},
+		{"box touching a corner", box, vector.Vector{X: -1, Y: 1}, vector.Vector{X: 1, Y: 3}, false, [2]float64{}},
+		{"u across both arms", u, vector.Vector{X: -1, Y: 2}, vector.Vector{X: 4, Y: 2}, false, [2]float64{}},
+		{"u across one arm", u, vector.Vector{X: -1, Y: 2}, vector.Vector{X: 1.5, Y: 2}, false, [2]float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPolygon(tt.outline, 1, true)
+			p.Velocity = vector.Vector{X: 1, Y: 0}
+			p.AngularVelocity = 0.5
+			left, right := Slice(p, tt.a, tt.b)
+			if !tt.cut {
+				if left != nil || right != nil {
+					t.Fatalf("got pieces %v and %v, want none", left, right)
+				}
+				return
+			}
+			if left == nil || right == nil {
+				t.Fatal("got no pieces")
+			}
+			areas := [2]float64{Area(left.Vertices), Area(right.Vertices)}
+			if areas[0] > areas[1] {
+				areas[0], areas[1] = areas[1], areas[0]
+			}
+			for i := range areas {
+				if math.Abs(areas[i]-tt.areas[i]) > 1e-9 {
+					t.Errorf("areas = %v, want %v", areas, tt.areas)
+				}
+			}
+			if mass := left.Mass + right.Mass; math.Abs(mass-p.Mass) > 1e-9 {
+				t.Errorf("pieces weigh %v, want %v", mass, p.Mass)
+			}
+			// The pieces carry the momentum of the polygon between them
+			momentum := left.Velocity.Scale(left.Mass).Add(right.Velocity.Scale(right.Mass))
+			want := p.Velocity.Scale(p.Mass)
+			if vector.Distance(momentum, want) > 1e-9 {
+				t.Errorf("momentum = %v, want %v", momentum, want)
+			}
+			for _, piece := range []*Polygon{left, right} {
+				if piece.AngularVelocity != p.AngularVelocity {
+					t.Errorf("angular velocity = %v, want %v", piece.AngularVelocity, p.AngularVelocity)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package polygon
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Voronoi splits a polygon into cells, one for each site, holding the points
+// closer to that site than to any other. Cells of convex outlines are convex.
+// A concave outline may split a cell in more than one piece, and sites whose
+// cells miss the outline give none, so there may be more or fewer cells than
+// sites.
+func Voronoi(outline, sites []vector.Vector) [][]vector.Vector {
+	outline = CounterClockwise(outline)
+	convex := IsConvex(outline)
+	bounds := outline
+	if !convex {
+		lo := vector.Vector{X: math.Inf(1), Y: math.Inf(1)}
+		hi := vector.Vector{X: math.Inf(-1), Y: math.Inf(-1)}
+		for _, v := range outline {
+			lo = vector.Vector{X: math.Min(lo.X, v.X), Y: math.Min(lo.Y, v.Y)}
+			hi = vector.Vector{X: math.Max(hi.X, v.X), Y: math.Max(hi.Y, v.Y)}
+		}
+		bounds = []vector.Vector{lo, {X: hi.X, Y: lo.Y}, hi, {X: lo.X, Y: hi.Y}}
+	}
+
+	var cells [][]vector.Vector
+	for i, site := range sites {
+		cell := bounds
+		for j, other := range sites {
+			if i == j || other == site {
+				continue
+			}
+			// Keep the side of the bisector nearer to site
+			mid := site.Add(other).Scale(0.5)
+			d := other.Sub(site)
+			cell = clipHalfPlane(cell, mid, mid.Add(vector.Vector{X: -d.Y, Y: d.X}))
+			if len(cell) < 3 {
+				break
+			}
+		}
+		if len(cell) < 3 || Area(cell) == 0 {
+			continue
+		}
+		if convex {
+			cells = append(cells, cell)
+			continue
+		}
+		for _, r := range Intersection([]Region{{Outline: outline}}, []Region{{Outline: cell}}) {
+			cells = append(cells, r.Outline)
+		}
+	}
+	return cells
+}
+
+// clipHalfPlane returns the part of a convex polygon to the left of the line
+// through a and b in a y-up frame (Sutherland-Hodgman).
+func clipHalfPlane(vertices []vector.Vector, a, b vector.Vector) []vector.Vector {
+	var out []vector.Vector
+	for i := range vertices {
+		cur := vertices[i]
+		next := vertices[(i+1)%len(vertices)]
+		dc := turn(a, b, cur)
+		dn := turn(a, b, next)
+		if dc >= 0 {
+			out = append(out, cur)
+		}
+		if (dc > 0 && dn < 0) || (dc < 0 && dn > 0) {
+			out = append(out, cur.Add(next.Sub(cur).Scale(dc/(dc-dn))))
+		}
+	}
+	return out
+}
@@ -0,0 +1,169 @@
+package rigidbody
+
+import (
+	"github.com/rudransh61/Physix-go/pkg/material"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Filter decides which fixtures collide. Two fixtures collide when each one's
+// Category is in the other's Mask, unless they share a Group: a positive group
+// always collides and a negative group never does. The zero Filter collides
+// with everything.
+type Filter struct {
+	Category uint16 // Bit of this fixture; 0 means 1
+	Mask     uint16 // Categories it collides with; 0 means all
+	Group    int
+}
+
+// ShouldCollide reports whether fixtures with filters a and b collide.
+func ShouldCollide(a, b Filter) bool {
+	if a.Group != 0 && a.Group == b.Group {
+		return a.Group > 0
+	}
+	return a.mask()&b.category() != 0 && b.mask()&a.category() != 0
+}
+
+func (f Filter) category() uint16 {
+	if f.Category == 0 {
+		return 1
+	}
+	return f.Category
+}
+
+func (f Filter) mask() uint16 {
+	if f.Mask == 0 {
+		return 0xFFFF
+	}
+	return f.Mask
+}
+
+// Fixture is one shape of a body made of several, such as a table top and its
+// legs. Each fixture has its own placement in body space, material, collision
+// filter and density; the body's mass, centroid and inertia combine them all.
+type Fixture struct {
+	Shape    string        // "Circle", "Rectangle", "Capsule" or "Polygon"
+	Offset   vector.Vector // Centre of the shape in body space
+	Angle    float64       // Rotation of the shape in body space
+	Width    float64
+	Height   float64
+	Radius   float64
+	Vertices []vector.Vector // Convex outline of a polygon about Offset
+	Density  float64         // 0 takes the density of Material
+	Material *material.Material
+	Filter   Filter
+	IsSensor bool // Sensors report overlaps but are never pushed apart and have no mass
+
+	Area        float64 // Set by the constructors
+	UnitInertia float64 // Inertia about Offset at a density of 1, set by the constructors
+}
+
+// NewCircleFixture creates a circle fixture centred at offset.
+func NewCircleFixture(offset vector.Vector, radius, density float64) *Fixture {
+	return newFixture(&Fixture{Shape: "Circle", Offset: offset, Radius: radius, Density: density})
+}
+
+// NewBoxFixture creates a rectangle fixture centred at offset and turned by angle.
+func NewBoxFixture(offset vector.Vector, width, height, angle, density float64) *Fixture {
+	return newFixture(&Fixture{Shape: "Rectangle", Offset: offset, Width: width, Height: height, Angle: angle, Density: density})
+}
+
+// NewCapsuleFixture creates a capsule fixture centred at offset. Its segment
+// runs along the fixture's Y axis, turned by angle.
+func NewCapsuleFixture(offset vector.Vector, length, radius, angle, density float64) *Fixture {
+	return newFixture(&Fixture{Shape: "Capsule", Offset: offset, Height: length, Radius: radius, Angle: angle, Density: density})
+}
+
+func newFixture(f *Fixture) *Fixture {
+	probe := RigidBody{Shape: f.Shape, Width: f.Width, Height: f.Height, Radius: f.Radius}
+	f.Area = probe.Area()
+	f.UnitInertia = probe.shapeInertia(f.Area)
+	return f
+}
+
+// density returns the density of the fixture, falling back to its material
+// and then to material.Default.
+func (f *Fixture) density() float64 {
+	if f.Density > 0 {
+		return f.Density
+	}
+	if f.Material != nil && f.Material.Density > 0 {
+		return f.Material.Density
+	}
+	return material.Default.Density
+}
+
+// Transform returns the placement of the fixture in world space.
+func (f *Fixture) Transform(rb *RigidBody) Transform {
+	return Transform{Position: rb.WorldPoint(f.Offset), Angle: rb.Angle + f.Angle}
+}
+
+// AddFixture attaches a fixture to the body and updates its mass data. The
+// offset of the fixture is measured from where the body's Position was before
+// its first fixture, so fixtures can be added one by one even though every
+// addition moves the centroid.
+func (rb *RigidBody) AddFixture(f *Fixture) {
+	f.Offset = f.Offset.Add(rb.frameOrigin)
+	rb.Fixtures = append(rb.Fixtures, f)
+	rb.ResetMassData()
+}
+
+// RemoveFixture detaches a fixture from the body and updates its mass data.
+func (rb *RigidBody) RemoveFixture(f *Fixture) {
+	for i, g := range rb.Fixtures {
+		if g == f {
+			rb.Fixtures = append(rb.Fixtures[:i], rb.Fixtures[i+1:]...)
+			break
+		}
+	}
+	rb.ResetMassData()
+}
+
+// ResetMassData computes the mass, centroid and inertia of the body from its
+// fixtures. The body is moved so Position is the new centroid, with the
+// fixture offsets shifted to match, so the fixtures stay where they were.
+func (rb *RigidBody) ResetMassData() {
+	var centroid vector.Vector
+	mass := 0.0
+	for _, f := range rb.Fixtures {
+		if f.IsSensor {
+			continue
+		}
+		m := f.density() * f.Area
+		mass += m
+		centroid = centroid.Add(f.Offset.Scale(m))
+	}
+	if mass > 0 {
+		centroid = centroid.Scale(1 / mass)
+		rb.Position = rb.WorldPoint(centroid)
+		for _, f := range rb.Fixtures {
+			f.Offset = f.Offset.Sub(centroid)
+		}
+		rb.frameOrigin = rb.frameOrigin.Sub(centroid)
+	}
+	rb.Mass = mass
+	rb.Inertia = rb.shapeInertia(mass)
+	rb.InvMass = 0
+	rb.InvInertia = 0
+	if mass > 0 {
+		rb.InvMass = 1 / mass
+	}
+	if rb.Inertia > 0 {
+		rb.InvInertia = 1 / rb.Inertia
+	}
+}
+
+// fixtureMass returns the mass of the fixtures at their densities and their
+// inertia about the body origin.
+func (rb *RigidBody) fixtureMass() (float64, float64) {
+	mass, inertia := 0.0, 0.0
+	for _, f := range rb.Fixtures {
+		if f.IsSensor {
+			continue
+		}
+		d := f.density()
+		m := d * f.Area
+		mass += m
+		inertia += d*f.UnitInertia + m*f.Offset.InnerProduct(f.Offset)
+	}
+	return mass, inertia
+}
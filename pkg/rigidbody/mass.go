@@ -0,0 +1,151 @@
+package rigidbody
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/pkg/material"
+)
+
+// Area returns the area of a circle, rectangle or capsule body, or the total
+// area of its solid fixtures, and 0 for other shapes.
+func (rb *RigidBody) Area() float64 {
+	if len(rb.Fixtures) > 0 {
+		area := 0.0
+		for _, f := range rb.Fixtures {
+			if !f.IsSensor {
+				area += f.Area
+			}
+		}
+		return area
+	}
+	switch rb.Shape {
+	case "Circle":
+		return math.Pi * rb.Radius * rb.Radius
+	case "Rectangle":
+		return rb.Width * rb.Height
+	case "Capsule":
+		return 2*rb.Radius*rb.Height + math.Pi*rb.Radius*rb.Radius
+	}
+	return 0
+}
+
+// SetMass sets the mass of the body along with its inverse mass, moment of
+// inertia and inverse inertia. A mass of 0 or less makes the body immovable
+// by forces and contacts.
+func (rb *RigidBody) SetMass(mass float64) {
+	rb.Mass = mass
+	rb.Inertia = rb.shapeInertia(mass)
+	rb.InvMass = 0
+	rb.InvInertia = 0
+	if mass > 0 {
+		rb.InvMass = 1 / mass
+	}
+	if rb.Inertia > 0 {
+		rb.InvInertia = 1 / rb.Inertia
+	}
+}
+
+// SetDensity computes the mass of the body from its shape area and density.
+func (rb *RigidBody) SetDensity(density float64) {
+	rb.SetMass(density * rb.Area())
+}
+
+// SetMaterial gives the body a material. Its restitution is copied to the
+// body and, if the material has a density, the mass is computed from it.
+func (rb *RigidBody) SetMaterial(m *material.Material) {
+	rb.Material = m
+	rb.Restitution = m.Restitution
+	if m.Density > 0 && rb.Area() > 0 {
+		rb.SetDensity(m.Density)
+	}
+}
+
+// Surface returns the material of the body. Bodies without one get a copy of
+// material.Default carrying their own Restitution.
+func (rb *RigidBody) Surface() *material.Material {
+	if rb.Material != nil {
+		return rb.Material
+	}
+	m := *material.Default
+	m.Restitution = rb.Restitution
+	return &m
+}
+
+// InverseMass returns 1/Mass for dynamic bodies and 0 for static, kinematic
+// and massless ones. The InvMass cached by SetMass is only used while it
+// still matches Mass, so assigning Mass directly also works.
+func (rb *RigidBody) InverseMass() float64 {
+	if !rb.IsDynamic() || rb.Mass <= 0 {
+		return 0
+	}
+	if rb.massCached() {
+		return rb.InvMass
+	}
+	return 1 / rb.Mass
+}
+
+// InverseInertia returns 1/Inertia for dynamic bodies and 0 for static,
+// kinematic and massless ones. If Mass was assigned directly after SetMass,
+// the moment of inertia is scaled with it. An infinite Inertia keeps a body
+// from turning, as for points that should slide rather than roll.
+func (rb *RigidBody) InverseInertia() float64 {
+	if !rb.IsDynamic() || rb.Mass <= 0 {
+		return 0
+	}
+	inertia := rb.Inertia
+	switch {
+	case math.IsInf(inertia, 1):
+		return 0
+	case rb.massCached():
+		if rb.InvInertia > 0 && rb.InvInertia == 1/inertia {
+			return rb.InvInertia
+		}
+	case rb.InvMass > 0 && inertia > 0:
+		// Mass changed since SetMass; the shape, and so the spread of mass, did not
+		inertia *= rb.Mass * rb.InvMass
+	}
+	if inertia <= 0 {
+		inertia = rb.shapeInertia(rb.Mass)
+	}
+	if inertia > 0 {
+		return 1 / inertia
+	}
+	return 0
+}
+
+// massCached reports whether InvMass was set for the current Mass.
+func (rb *RigidBody) massCached() bool {
+	return rb.InvMass > 0 && rb.InvMass == 1/rb.Mass
+}
+
+// shapeInertia returns the moment of inertia of a circle, rectangle or capsule
+// of the given mass about its centre. The mass of a body with fixtures is
+// spread over them in proportion to their densities.
+func (rb *RigidBody) shapeInertia(mass float64) float64 {
+	if mass <= 0 {
+		return 0
+	}
+	if len(rb.Fixtures) > 0 {
+		m, inertia := rb.fixtureMass()
+		if m == 0 {
+			return 0
+		}
+		return inertia * mass / m
+	}
+	switch rb.Shape {
+	case "Circle":
+		return 0.5 * mass * rb.Radius * rb.Radius // Solid disk
+	case "Rectangle":
+		return mass * (rb.Width*rb.Width + rb.Height*rb.Height) / 12
+	case "Capsule":
+		// A box between two half disks; each half disk sits past the end of
+		// the box, 4r/3π from its flat side
+		r, h := rb.Radius, rb.Height/2
+		boxMass := mass * 2 * r * rb.Height / rb.Area()
+		diskMass := mass - boxMass
+		boxInertia := boxMass * (4*r*r + rb.Height*rb.Height) / 12
+		diskInertia := diskMass * (0.5*r*r + h*h + 2*h*4*r/(3*math.Pi))
+		return boxInertia + diskInertia
+	}
+	return 0
+}
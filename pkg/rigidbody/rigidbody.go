@@ -1,27 +1,74 @@
 package rigidbody
 
 import (
+	"github.com/rudransh61/Physix-go/pkg/material"
 	"github.com/rudransh61/Physix-go/pkg/vector"
 	"math"
 )
 
+// Deprecated: give immovable bodies the Static or Kinematic type instead.
+// Their inverse mass is 0, so they never take part in momentum exchange.
 var Infinite_mass float64 = 1e10
 
+// BodyType tells the solver how a body moves.
+type BodyType int
+
+const (
+	Unset     BodyType = iota // No type given: Dynamic if IsMovable, otherwise Static
+	Static                    // Never moves, whatever IsMovable says
+	Kinematic                 // Moves by its velocity only, ignores forces and contacts
+	Dynamic                   // Moves under forces and contacts
+)
+
 // RigidBody represents a 2D rigid body.
 type RigidBody struct {
-	Position    vector.Vector
+	Position    vector.Vector // Centre of the body, for circles and rectangles alike
+	Angle       float64       // Orientation in radians about Position
 	Velocity    vector.Vector
 	Force       vector.Vector
 	Mass        float64 
+	InvMass     float64 // 1/Mass, set by SetMass; see InverseMass
+	Inertia     float64 // Moment of inertia about the centre of mass
+	InvInertia  float64 // 1/Inertia, set by SetMass; see InverseInertia
 	Shape       string
 	Width       float64
 	Height      float64
 	Radius      float64
-	IsMovable   bool
+	IsMovable   bool // Kept for older code: a body with IsMovable and an Unset Type is Dynamic
+	Type        BodyType
 	Torque      float64 
     AngularVelocity float64 
     AngularAcceleration float64 
-	Restitution  float64
+	Restitution  float64 // Used when the body has no Material
+	Material     *material.Material
+	IsSleeping   bool    // Sleeping bodies are skipped by the integrator and the collision solver
+	SleepTime    float64 // Time the body has spent below the sleep thresholds
+	Fixtures     []*Fixture // Shapes of a body made of several; when set they replace Shape
+	frameOrigin  vector.Vector // Where fixture offsets are measured from, relative to Position
+}
+
+// SetType changes the body type and keeps IsMovable in sync with it.
+func (rb *RigidBody) SetType(t BodyType) {
+	rb.Type = t
+	rb.IsMovable = t == Dynamic
+	if t != Dynamic {
+		rb.WakeUp()
+	}
+}
+
+// IsDynamic reports whether forces and contacts move the body.
+func (rb *RigidBody) IsDynamic() bool {
+	return rb.Type == Dynamic || (rb.Type == Unset && rb.IsMovable)
+}
+
+// IsKinematic reports whether the body moves only by its own velocity.
+func (rb *RigidBody) IsKinematic() bool {
+	return rb.Type == Kinematic
+}
+
+// IsStatic reports whether the body never moves.
+func (rb *RigidBody) IsStatic() bool {
+	return rb.Type == Static || (rb.Type == Unset && !rb.IsMovable)
 }
 
 // Rotate any body
@@ -47,25 +94,42 @@ func (rb *RigidBody) rotateCoordinates(theta float64) (vector.Vector) {
 }
 
 
-// UpdateRotation updates the rotation of the rigid body based on its angular velocity.
+// UpdateRotation turns the rigid body by its angular velocity over dt. It
+// ignores torque; physix.UpdateRotation also applies that.
 func (rb *RigidBody) UpdateRotation(dt float64) {
-    // Update rotation based on angular velocity
-    angle := rb.AngularVelocity * dt
-    rb.Position.X = math.Cos(angle)*rb.Position.X - math.Sin(angle)*rb.Position.Y
-    rb.Position.Y = math.Sin(angle)*rb.Position.X + math.Cos(angle)*rb.Position.Y
+    rb.Angle += rb.AngularVelocity * dt
 }
 
 // ApplyTorque applies a torque to the rigid body.
 func (rb *RigidBody) ApplyTorque(torque float64) {
+    rb.WakeUp()
     rb.Torque += torque
 }
 
+// WakeUp wakes a sleeping body and resets its sleep timer.
+func (rb *RigidBody) WakeUp() {
+	rb.IsSleeping = false
+	rb.SleepTime = 0
+}
+
+// Sleep puts the body to sleep and clears its motion.
+func (rb *RigidBody) Sleep() {
+	rb.IsSleeping = true
+	rb.Velocity = vector.Vector{}
+	rb.AngularVelocity = 0
+	rb.AngularAcceleration = 0
+}
+
 
 // IMPART Impulse on a body
 func (rb *RigidBody) ApplyImpulse(impulse vector.Vector) {
+    if !rb.IsDynamic() {
+        return
+    }
+    rb.WakeUp()
+
     // Calculate the change in velocity using impulse and mass
-   
-    change_velocity := impulse.Scale(1/rb.Mass);
+    change_velocity := impulse.Scale(rb.InverseMass());
     rb.Velocity = rb.Velocity.Add(change_velocity)
 
     // rb.Rotation += rb.Torque / rb.Mass // Update rotation based on torque and mass
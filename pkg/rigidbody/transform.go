@@ -0,0 +1,93 @@
+package rigidbody
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Transform is a position and a rotation that place body-space points in the world.
+type Transform struct {
+	Position vector.Vector
+	Angle    float64 // Radians
+}
+
+// Apply converts a point from body space to world space.
+func (t Transform) Apply(local vector.Vector) vector.Vector {
+	return local.Rotate(t.Angle).Add(t.Position)
+}
+
+// Inverse converts a point from world space to body space.
+func (t Transform) Inverse(world vector.Vector) vector.Vector {
+	return world.Sub(t.Position).Rotate(-t.Angle)
+}
+
+// Transform returns the current placement of the body.
+func (rb *RigidBody) Transform() Transform {
+	return Transform{Position: rb.Position, Angle: rb.Angle}
+}
+
+// WorldPoint converts a point from body space to world space.
+func (rb *RigidBody) WorldPoint(local vector.Vector) vector.Vector {
+	return rb.Transform().Apply(local)
+}
+
+// LocalPoint converts a point from world space to body space.
+func (rb *RigidBody) LocalPoint(world vector.Vector) vector.Vector {
+	return rb.Transform().Inverse(world)
+}
+
+// WorldVector rotates a direction from body space to world space.
+func (rb *RigidBody) WorldVector(local vector.Vector) vector.Vector {
+	return local.Rotate(rb.Angle)
+}
+
+// VelocityAt returns the velocity of a world point moving with the body.
+func (rb *RigidBody) VelocityAt(world vector.Vector) vector.Vector {
+	return rb.Velocity.Add(vector.CrossScalar(rb.AngularVelocity, world.Sub(rb.Position)))
+}
+
+// Corners returns the world-space corners of a rectangle body, in the order
+// top-left, top-right, bottom-right, bottom-left before rotation.
+func (rb *RigidBody) Corners() []vector.Vector {
+	hw, hh := rb.Width/2, rb.Height/2
+	return []vector.Vector{
+		rb.WorldPoint(vector.Vector{X: -hw, Y: -hh}),
+		rb.WorldPoint(vector.Vector{X: hw, Y: -hh}),
+		rb.WorldPoint(vector.Vector{X: hw, Y: hh}),
+		rb.WorldPoint(vector.Vector{X: -hw, Y: hh}),
+	}
+}
+
+// SegmentEnds returns the world-space ends of the inner segment of a capsule or
+// segment body. The segment runs along the body's local Y axis.
+func (rb *RigidBody) SegmentEnds() (vector.Vector, vector.Vector) {
+	h := rb.Height / 2
+	return rb.WorldPoint(vector.Vector{Y: -h}), rb.WorldPoint(vector.Vector{Y: h})
+}
+
+// NewSegment creates a static segment from a to b, for ramps and walls.
+func NewSegment(a, b vector.Vector) *RigidBody {
+	d := b.Sub(a)
+	return &RigidBody{
+		Position: a.Add(b).Scale(0.5),
+		Angle:    math.Atan2(d.Y, d.X) - math.Pi/2,
+		Shape:    "Segment",
+		Height:   d.Magnitude(),
+		Type:     Static,
+	}
+}
+
+// NewCapsule creates a dynamic upright capsule: a segment of the given length
+// along the local Y axis, grown by radius. Its mass comes from density.
+func NewCapsule(center vector.Vector, length, radius, density float64) *RigidBody {
+	rb := &RigidBody{
+		Position: center,
+		Shape:    "Capsule",
+		Height:   length,
+		Radius:   radius,
+		Type:     Dynamic,
+	}
+	rb.SetDensity(density)
+	return rb
+}
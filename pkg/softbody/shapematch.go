@@ -0,0 +1,138 @@
+package softbody
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/dynamics/collision"
+	physix "github.com/rudransh61/Physix-go/dynamics/physics"
+	"github.com/rudransh61/Physix-go/pkg/material"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// ShapeMatch is a meshless soft body (Müller et al., "Meshless Deformations
+// Based on Shape Matching"). Every step its points are pulled towards their
+// rest shape, turned and moved to fit where the points are now. The rest
+// shape is only ever rotated, never mirrored, so the body cannot turn inside
+// out however hard it is squashed.
+type ShapeMatch struct {
+	Points     []*rigidbody.RigidBody
+	Rest       []vector.Vector // Rest shape of each point, about the rest centre of mass
+	Stiffness  float64         // Share of the way to the goal shape covered each step, from 0 to 1
+	Plasticity float64         // Share of a deformation past YieldLimit kept in the rest shape each step
+	YieldLimit float64         // Distance a point can be pushed from its goal before it deforms for good
+	Materials  *material.Table // Friction and restitution of chosen material pairs in Resolve, nil for none
+}
+
+// NewShapeMatch creates a shape-matching body with its rest shape where the
+// points are now. The mass is shared evenly by the points. A stiffness of 1
+// keeps the shape rigid and lower values make it jelly-like; a plasticity
+// above 0 lets it dent.
+func NewShapeMatch(positions []vector.Vector, mass, stiffness, plasticity float64) *ShapeMatch {
+	s := &ShapeMatch{
+		Stiffness:  stiffness,
+		Plasticity: plasticity,
+	}
+	for _, v := range positions {
+		s.Points = append(s.Points, newPoint(v, mass/float64(len(positions))))
+	}
+	center := s.Center()
+	s.Rest = make([]vector.Vector, len(positions))
+	for i, v := range positions {
+		s.Rest[i] = v.Sub(center)
+	}
+	return s
+}
+
+// Center returns the centre of mass of the points.
+func (s *ShapeMatch) Center() vector.Vector {
+	var c vector.Vector
+	total := 0.0
+	for _, p := range s.Points {
+		c = c.Add(p.Position.Scale(p.Mass))
+		total += p.Mass
+	}
+	if total == 0 {
+		return c
+	}
+	return c.Scale(1 / total)
+}
+
+// Angle returns the rotation that best fits the rest shape to the points.
+func (s *ShapeMatch) Angle() float64 {
+	center := s.Center()
+	dot, cross := 0.0, 0.0
+	for i, p := range s.Points {
+		q := s.Rest[i]
+		d := p.Position.Sub(center)
+		dot += p.Mass * q.InnerProduct(d)
+		cross += p.Mass * q.Cross(d)
+	}
+	return math.Atan2(cross, dot)
+}
+
+// Goals returns where each point would be if the body held its rest shape.
+func (s *ShapeMatch) Goals() []vector.Vector {
+	center, angle := s.Center(), s.Angle()
+	goals := make([]vector.Vector, len(s.Points))
+	for i, q := range s.Rest {
+		goals[i] = q.Rotate(angle).Add(center)
+	}
+	return goals
+}
+
+// Update pulls the points towards their goals, lets the rest shape yield
+// to deformations past YieldLimit, then moves the points under gravity, an
+// acceleration such as {0, 9.8}.
+func (s *ShapeMatch) Update(gravity vector.Vector, dt float64) {
+	if dt <= 0 {
+		return
+	}
+	angle := s.Angle()
+	goals := s.Goals()
+	for i, p := range s.Points {
+		if !p.IsDynamic() {
+			continue
+		}
+		pull := goals[i].Sub(p.Position)
+		p.Velocity = p.Velocity.Add(pull.Scale(s.Stiffness / dt))
+		if s.Plasticity > 0 && pull.Magnitude() > s.YieldLimit {
+			// Move the rest shape part of the way to the deformed one,
+			// measured in the rest frame
+			deformed := p.Position.Sub(goals[i]).Rotate(-angle)
+			s.Rest[i] = s.Rest[i].Add(deformed.Scale(s.Plasticity))
+		}
+	}
+	if s.Plasticity > 0 {
+		s.recenter()
+	}
+	for _, p := range s.Points {
+		physix.ApplyForce(p, gravity.Scale(p.Mass), dt)
+	}
+}
+
+// recenter keeps the rest shape about its centre of mass after it yields.
+func (s *ShapeMatch) recenter() {
+	var c vector.Vector
+	total := 0.0
+	for i, p := range s.Points {
+		c = c.Add(s.Rest[i].Scale(p.Mass))
+		total += p.Mass
+	}
+	if total == 0 {
+		return
+	}
+	c = c.Scale(1 / total)
+	for i := range s.Rest {
+		s.Rest[i] = s.Rest[i].Sub(c)
+	}
+}
+
+// Resolve collides the points of the body with another body, like small
+// circles.
+func (s *ShapeMatch) Resolve(other *rigidbody.RigidBody) {
+	resolver := collision.Resolver{Materials: s.Materials}
+	for _, p := range s.Points {
+		resolver.Resolve(p, other)
+	}
+}
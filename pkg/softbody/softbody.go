@@ -0,0 +1,230 @@
+package softbody
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/dynamics/collision"
+	physix "github.com/rudransh61/Physix-go/dynamics/physics"
+	"github.com/rudransh61/Physix-go/pkg/material"
+	"github.com/rudransh61/Physix-go/pkg/polygon"
+	"github.com/rudransh61/Physix-go/pkg/rigidbody"
+	"github.com/rudransh61/Physix-go/pkg/spring"
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// PointRadius is the radius of the hull points, which keeps the hull off
+// other bodies.
+var PointRadius float64 = 2
+
+// Body is a closed soft shape: point masses around an outline, held together
+// by springs along the perimeter and across it, and kept inflated by gas
+// pressure that pushes back when the enclosed area shrinks.
+type Body struct {
+	Points   []*rigidbody.RigidBody // Hull points in outline order
+	Springs  []*spring.Spring       // Perimeter springs first, then shear springs
+	Pressure float64                // Pressure per unit of relative area lost
+	RestArea float64                // Area the gas keeps the body at
+
+	Materials *material.Table // Friction and restitution of chosen material pairs in collisions, nil for none
+}
+
+// New creates a soft body from an outline. The mass is shared evenly by the
+// hull points, and every spring gets the given stiffness and damping.
+func New(outline []vector.Vector, mass, stiffness, damping, pressure float64) *Body {
+	b := &Body{
+		Pressure: pressure,
+		RestArea: polygon.Area(outline),
+	}
+	for _, v := range outline {
+		b.Points = append(b.Points, newPoint(v, mass/float64(len(outline))))
+	}
+	n := len(b.Points)
+	for i := range b.Points {
+		b.Springs = append(b.Springs, spring.NewSpring(b.Points[i], b.Points[(i+1)%n], stiffness, damping))
+	}
+	if n > 3 {
+		// Shear springs skip a point, so the hull resists folding in on itself
+		for i := range b.Points {
+			b.Springs = append(b.Springs, spring.NewSpring(b.Points[i], b.Points[(i+2)%n], stiffness, damping))
+		}
+	}
+	return b
+}
+
+// newPoint creates a point mass of radius PointRadius.
+func newPoint(position vector.Vector, mass float64) *rigidbody.RigidBody {
+	p := &rigidbody.RigidBody{
+		Position: position,
+		Shape:    "Circle",
+		Radius:   PointRadius,
+		Type:     rigidbody.Dynamic,
+	}
+	p.SetMass(mass)
+	// Points slide rather than roll, so friction holds the body in place
+	p.Inertia, p.InvInertia = math.Inf(1), 0
+	return p
+}
+
+// NewCircle creates a round soft body from points spaced evenly around a
+// circle.
+func NewCircle(center vector.Vector, radius float64, points int, mass, stiffness, damping, pressure float64) *Body {
+	outline := make([]vector.Vector, points)
+	for i := range outline {
+		angle := 2 * math.Pi * float64(i) / float64(points)
+		outline[i] = center.Add(vector.Vector{X: radius * math.Cos(angle), Y: radius * math.Sin(angle)})
+	}
+	return New(outline, mass, stiffness, damping, pressure)
+}
+
+// Hull returns the positions of the hull points.
+func (b *Body) Hull() []vector.Vector {
+	hull := make([]vector.Vector, len(b.Points))
+	for i, p := range b.Points {
+		hull[i] = p.Position
+	}
+	return hull
+}
+
+// Area returns the area enclosed by the hull.
+func (b *Body) Area() float64 {
+	return polygon.Area(b.Hull())
+}
+
+// Center returns the mean position of the hull points.
+func (b *Body) Center() vector.Vector {
+	var c vector.Vector
+	for _, p := range b.Points {
+		c = c.Add(p.Position)
+	}
+	return c.Scale(1 / float64(len(b.Points)))
+}
+
+// Update applies the spring and pressure forces, then moves the hull points
+// under gravity, an acceleration such as {0, 9.8}.
+func (b *Body) Update(gravity vector.Vector, dt float64) {
+	for _, s := range b.Springs {
+		s.ApplyForce()
+	}
+	b.applyPressure(dt)
+	for _, p := range b.Points {
+		physix.ApplyForce(p, gravity.Scale(p.Mass), dt)
+	}
+}
+
+// applyPressure pushes every hull edge outwards with a force proportional to
+// its length and to the share of the rest area the body has lost. A body
+// stretched past its rest area is pulled in instead.
+func (b *Body) applyPressure(dt float64) {
+	hull := b.Hull()
+	area := polygon.SignedArea(hull)
+	if area == 0 || b.RestArea == 0 {
+		return
+	}
+	pressure := b.Pressure * (b.RestArea - math.Abs(area)) / b.RestArea
+	// Turn the edge a quarter to the outside for either winding
+	side := math.Copysign(1, area)
+	for i, p := range b.Points {
+		q := b.Points[(i+1)%len(b.Points)]
+		e := q.Position.Sub(p.Position)
+		force := vector.Vector{X: e.Y, Y: -e.X}.Scale(side * pressure / 2)
+		p.Velocity = p.Velocity.Add(force.Scale(p.InverseMass() * dt))
+		q.Velocity = q.Velocity.Add(force.Scale(q.InverseMass() * dt))
+	}
+}
+
+// Resolve collides the soft body with another body. The hull points are
+// resolved against it like small circles, and a circle body is also kept out
+// of the hull edges between them, so it cannot slip through the skin.
+func (b *Body) Resolve(other *rigidbody.RigidBody) {
+	resolver := collision.Resolver{Materials: b.Materials}
+	for _, p := range b.Points {
+		resolver.Resolve(p, other)
+	}
+	if other.Shape == "Circle" && other.IsDynamic() {
+		b.resolveParticle(other, other.Radius)
+	}
+}
+
+// ResolveBody collides two soft bodies by keeping the hull points of each
+// out of the other's hull.
+func (b *Body) ResolveBody(other *Body) {
+	for _, p := range other.Points {
+		b.resolveParticle(p, p.Radius)
+	}
+	for _, p := range b.Points {
+		other.resolveParticle(p, p.Radius)
+	}
+}
+
+// resolveParticle pushes a circle of the given radius out of the nearest hull
+// edge, if it is inside the hull or touching an edge, and stops it moving
+// into the edge. The push and the impulse are shared between the circle and
+// the two ends of the edge by their inverse masses.
+func (b *Body) resolveParticle(c *rigidbody.RigidBody, radius float64) {
+	hull := b.Hull()
+	inside := polygon.Contains(hull, c.Position)
+	edge, t, closest := -1, 0.0, vector.Vector{}
+	best := math.Inf(1)
+	for i := range hull {
+		a, e := hull[i], hull[(i+1)%len(hull)].Sub(hull[i])
+		s := 0.0
+		if length := e.InnerProduct(e); length > 0 {
+			s = math.Max(0, math.Min(1, c.Position.Sub(a).InnerProduct(e)/length))
+		}
+		point := a.Add(e.Scale(s))
+		if d := vector.Distance(point, c.Position); d < best {
+			edge, t, closest, best = i, s, point, d
+		}
+	}
+	if edge < 0 || (!inside && best >= radius) {
+		return
+	}
+
+	pa, pb := b.Points[edge], b.Points[(edge+1)%len(b.Points)]
+	var normal vector.Vector // From the edge towards where the circle belongs
+	if best > 0 {
+		normal = c.Position.Sub(closest).Scale(1 / best)
+		if inside {
+			normal = normal.Scale(-1)
+		}
+	} else {
+		e := pb.Position.Sub(pa.Position)
+		normal = vector.Vector{X: e.Y, Y: -e.X}.Normalize().Scale(math.Copysign(1, polygon.SignedArea(hull)))
+	}
+	depth := radius - best
+	if inside {
+		depth = radius + best
+	}
+
+	imc, ima, imb := c.InverseMass(), pa.InverseMass(), pb.InverseMass()
+	wa, wb := 1-t, t
+	w := imc + wa*wa*ima + wb*wb*imb
+	if w == 0 {
+		return
+	}
+	push := depth * collision.CorrectionPercent / w
+	c.Position = c.Position.Add(normal.Scale(push * imc))
+	pa.Position = pa.Position.Sub(normal.Scale(push * wa * ima))
+	pb.Position = pb.Position.Sub(normal.Scale(push * wb * imb))
+
+	edgeVelocity := pa.Velocity.Scale(wa).Add(pb.Velocity.Scale(wb))
+	relative := c.Velocity.Sub(edgeVelocity)
+	vn := relative.InnerProduct(normal)
+	if vn >= 0 {
+		return
+	}
+	mix := collision.Resolver{Materials: b.Materials}.Mix(c, pa)
+	jn := -(1 + mix.Restitution) * vn / w
+	impulse := normal.Scale(jn)
+
+	// Friction along the edge, limited by the normal impulse
+	tangent := relative.Sub(normal.Scale(vn))
+	if speed := tangent.Magnitude(); speed > 0 {
+		jt := math.Min(speed/w, mix.DynamicFriction*jn)
+		impulse = impulse.Sub(tangent.Scale(jt / speed))
+	}
+	c.Velocity = c.Velocity.Add(impulse.Scale(imc))
+	pa.Velocity = pa.Velocity.Sub(impulse.Scale(wa * ima))
+	pb.Velocity = pb.Velocity.Sub(impulse.Scale(wb * imb))
+	c.WakeUp()
+}
@@ -40,12 +40,9 @@ func (s *Spring) ApplyForce() {
 	dampingForce := relativeVelocity.Scale(s.Damping)
 
 	// Apply forces
-	if s.BallA.IsMovable && s.BallA.Mass != 0 {
-		acc := force.Add((dampingForce)).Scale(1 / s.BallA.Mass)
-		s.BallA.Velocity = s.BallA.Velocity.Add(acc)
-	}
-	if s.BallB.IsMovable && s.BallB.Mass != 0 {
-		acc := force.Add((dampingForce)).Scale(1 / s.BallB.Mass)
-		s.BallB.Velocity = s.BallB.Velocity.Sub(acc)
-	}
+	// Static, kinematic and massless ends have an inverse mass of 0 and stay put
+	accA := force.Add((dampingForce)).Scale(s.BallA.InverseMass())
+	s.BallA.Velocity = s.BallA.Velocity.Add(accA)
+	accB := force.Add((dampingForce)).Scale(s.BallB.InverseMass())
+	s.BallB.Velocity = s.BallB.Velocity.Sub(accB)
 }
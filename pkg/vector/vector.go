@@ -64,3 +64,19 @@ func ComponentAlong(A, B Vector) Vector {
     scale := A.InnerProduct(B) / B.InnerProduct(B) // (A · B) / (|B|^2)
     return B.Scale(scale)        // Scale B to get projection
 }
+
+// Cross returns the z component of the 3D cross product of two vectors.
+func (v Vector) Cross(other Vector) float64 {
+	return v.X*other.Y - v.Y*other.X
+}
+
+// Rotate rotates the vector by angle radians.
+func (v Vector) Rotate(angle float64) Vector {
+	cos, sin := math.Cos(angle), math.Sin(angle)
+	return Vector{v.X*cos - v.Y*sin, v.X*sin + v.Y*cos}
+}
+
+// CrossScalar returns the cross product of a scalar (an angular velocity) and a vector.
+func CrossScalar(s float64, v Vector) Vector {
+	return Vector{-s * v.Y, s * v.X}
+}
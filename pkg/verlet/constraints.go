@@ -0,0 +1,144 @@
+package verlet
+
+import (
+	"math"
+
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Stick keeps two particles Length apart.
+type Stick struct {
+	A, B      *Particle
+	Length    float64
+	Stiffness float64 // Share of the error corrected each pass, from 0 to 1; 1 is rigid
+}
+
+// NewStick creates a rigid stick at the particles' current distance.
+func NewStick(a, b *Particle) *Stick {
+	return &Stick{A: a, B: b, Length: vector.Distance(a.Position, b.Position), Stiffness: 1}
+}
+
+// Relax moves the particles towards Length apart, the lighter one further.
+func (s *Stick) Relax() {
+	wa, wb := s.A.InverseMass(), s.B.InverseMass()
+	if wa+wb == 0 {
+		return
+	}
+	d := s.B.Position.Sub(s.A.Position)
+	distance := d.Magnitude()
+	if distance == 0 {
+		return
+	}
+	correction := d.Scale((distance - s.Length) / distance * s.Stiffness / (wa + wb))
+	s.A.Position = s.A.Position.Add(correction.Scale(wa))
+	s.B.Position = s.B.Position.Sub(correction.Scale(wb))
+}
+
+// Pin holds a particle at Position. Move Position to drag the particle.
+type Pin struct {
+	Particle *Particle
+	Position vector.Vector
+}
+
+// Relax puts the particle back on the pin.
+func (p *Pin) Relax() {
+	p.Particle.Position = p.Position
+}
+
+// Bound is a shape that holds particles in or out.
+type Bound interface {
+	// Constrain moves a particle that crossed the bound back to its edge.
+	Constrain(p *Particle)
+}
+
+// Box is an axis-aligned rectangle that holds particles inside it, or out of
+// it when Solid.
+type Box struct {
+	Min, Max vector.Vector // Top-left and bottom-right corners
+	Solid    bool
+	Bounce   float64 // Share of the speed into the edge kept as the particle bounces off it
+}
+
+// Constrain implements Bound.
+func (b *Box) Constrain(p *Particle) {
+	if b.Solid {
+		b.pushOut(p)
+		return
+	}
+	r := p.Radius
+	if p.Position.X < b.Min.X+r {
+		bounce(p, vector.Vector{X: b.Min.X + r, Y: p.Position.Y}, vector.Vector{X: 1}, b.Bounce)
+	} else if p.Position.X > b.Max.X-r {
+		bounce(p, vector.Vector{X: b.Max.X - r, Y: p.Position.Y}, vector.Vector{X: -1}, b.Bounce)
+	}
+	if p.Position.Y < b.Min.Y+r {
+		bounce(p, vector.Vector{X: p.Position.X, Y: b.Min.Y + r}, vector.Vector{Y: 1}, b.Bounce)
+	} else if p.Position.Y > b.Max.Y-r {
+		bounce(p, vector.Vector{X: p.Position.X, Y: b.Max.Y - r}, vector.Vector{Y: -1}, b.Bounce)
+	}
+}
+
+// pushOut moves a particle out of a solid box through its nearest side.
+func (b *Box) pushOut(p *Particle) {
+	r := p.Radius
+	x, y := p.Position.X, p.Position.Y
+	if x <= b.Min.X-r || x >= b.Max.X+r || y <= b.Min.Y-r || y >= b.Max.Y+r {
+		return
+	}
+	left, right := x-(b.Min.X-r), b.Max.X+r-x
+	top, bottom := y-(b.Min.Y-r), b.Max.Y+r-y
+	switch min(left, right, top, bottom) {
+	case left:
+		bounce(p, vector.Vector{X: b.Min.X - r, Y: y}, vector.Vector{X: -1}, b.Bounce)
+	case right:
+		bounce(p, vector.Vector{X: b.Max.X + r, Y: y}, vector.Vector{X: 1}, b.Bounce)
+	case top:
+		bounce(p, vector.Vector{X: x, Y: b.Min.Y - r}, vector.Vector{Y: -1}, b.Bounce)
+	default:
+		bounce(p, vector.Vector{X: x, Y: b.Max.Y + r}, vector.Vector{Y: 1}, b.Bounce)
+	}
+}
+
+// Circle is a circle that holds particles inside it, or out of it when Solid.
+type Circle struct {
+	Center vector.Vector
+	Radius float64
+	Solid  bool
+	Bounce float64 // Share of the speed into the edge kept as the particle bounces off it
+}
+
+// Constrain implements Bound.
+func (c *Circle) Constrain(p *Particle) {
+	d := p.Position.Sub(c.Center)
+	distance := d.Magnitude()
+	if c.Solid {
+		limit := c.Radius + p.Radius
+		if distance >= limit {
+			return
+		}
+		n := vector.Vector{Y: -1} // Straight up from the centre
+		if distance > 0 {
+			n = d.Scale(1 / distance)
+		}
+		bounce(p, c.Center.Add(n.Scale(limit)), n, c.Bounce)
+		return
+	}
+	limit := math.Max(c.Radius-p.Radius, 0)
+	if distance <= limit {
+		return
+	}
+	n := d.Scale(1 / distance)
+	bounce(p, c.Center.Add(n.Scale(limit)), n.Scale(-1), c.Bounce)
+}
+
+// bounce moves a particle to position on an edge with normal n, pointing to
+// where the particle may be. Its motion into the edge is reflected, scaled by
+// restitution; its motion along the edge is kept.
+func bounce(p *Particle, position, n vector.Vector, restitution float64) {
+	moved := p.Position.Sub(p.Previous)
+	p.Position = position
+	if into := moved.InnerProduct(n); into < 0 {
+		moved = moved.Sub(n.Scale(into * (1 + restitution)))
+	}
+	p.Previous = p.Position.Sub(moved)
+}
@@ -0,0 +1,92 @@
+package verlet
+
+import (
+	"math"
+	"sort"
+
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+type cell struct{ X, Y int }
+
+// SpatialHash sorts particles into square cells so that only particles in
+// neighbouring cells are tested against each other. Cells should be at least
+// as wide as the largest particle.
+type SpatialHash struct {
+	CellSize float64
+	cells    map[cell][]*Particle
+	filled   []cell // Cells with particles in them, in the order they were filled
+}
+
+// NewSpatialHash creates an empty hash with cells of the given size.
+func NewSpatialHash(cellSize float64) *SpatialHash {
+	return &SpatialHash{CellSize: cellSize, cells: make(map[cell][]*Particle)}
+}
+
+func (h *SpatialHash) cellOf(v vector.Vector) cell {
+	return cell{int(math.Floor(v.X / h.CellSize)), int(math.Floor(v.Y / h.CellSize))}
+}
+
+// Clear empties the hash. Cells that were filled keep their memory for the
+// next use; cells left empty since the last Clear are dropped, so the hash
+// does not grow as particles wander.
+func (h *SpatialHash) Clear() {
+	for k, particles := range h.cells {
+		if len(particles) == 0 {
+			delete(h.cells, k)
+		} else {
+			h.cells[k] = particles[:0]
+		}
+	}
+	h.filled = h.filled[:0]
+}
+
+// Insert adds a particle to the cell of its position.
+func (h *SpatialHash) Insert(p *Particle) {
+	c := h.cellOf(p.Position)
+	if len(h.cells[c]) == 0 {
+		h.filled = append(h.filled, c)
+	}
+	h.cells[c] = append(h.cells[c], p)
+}
+
+// Near returns the particles in the cells around a position, out to radius.
+// Some of them may be further away than radius, but none closer is missed.
+func (h *SpatialHash) Near(position vector.Vector, radius float64) []*Particle {
+	var near []*Particle
+	lo := h.cellOf(position.Sub(vector.Vector{X: radius, Y: radius}))
+	hi := h.cellOf(position.Add(vector.Vector{X: radius, Y: radius}))
+	for x := lo.X; x <= hi.X; x++ {
+		for y := lo.Y; y <= hi.Y; y++ {
+			near = append(near, h.cells[cell{x, y}]...)
+		}
+	}
+	return near
+}
+
+// Pairs appends to pairs every pair of particles in the same or neighbouring
+// cells, each pair once. The cells are visited row by row, so the same
+// particles inserted in the same order always give the same pairs in the same
+// order.
+func (h *SpatialHash) Pairs(pairs [][2]*Particle) [][2]*Particle {
+	sort.Slice(h.filled, func(i, j int) bool {
+		a, b := h.filled[i], h.filled[j]
+		return a.Y < b.Y || a.Y == b.Y && a.X < b.X
+	})
+	// Half of the neighbours, so each pair of cells is visited once
+	neighbours := []cell{{1, 0}, {-1, 1}, {0, 1}, {1, 1}}
+	for _, c := range h.filled {
+		particles := h.cells[c]
+		for i, a := range particles {
+			for _, b := range particles[i+1:] {
+				pairs = append(pairs, [2]*Particle{a, b})
+			}
+			for _, n := range neighbours {
+				for _, b := range h.cells[cell{c.X + n.X, c.Y + n.Y}] {
+					pairs = append(pairs, [2]*Particle{a, b})
+				}
+			}
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,101 @@
+package verlet
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+func scatter(rng *rand.Rand, n int, size float64) []*Particle {
+	particles := make([]*Particle, n)
+	for i := range particles {
+		position := vector.Vector{X: rng.Float64() * size, Y: rng.Float64() * size}
+		particles[i] = NewParticle(position, 2, 1)
+	}
+	return particles
+}
+
+func TestPairsFindsEveryTouchingPair(t *testing.T) {
+	particles := scatter(rand.New(rand.NewSource(1)), 300, 200)
+	h := NewSpatialHash(4)
+	for _, p := range particles {
+		h.Insert(p)
+	}
+	found := make(map[[2]*Particle]int)
+	for _, pair := range h.Pairs(nil) {
+		if pair[0] == pair[1] {
+			t.Fatalf("particle %v paired with itself", pair[0].Position)
+		}
+		found[pair]++
+		found[[2]*Particle{pair[1], pair[0]}]++
+	}
+	for i, a := range particles {
+		for _, b := range particles[i+1:] {
+			n := found[[2]*Particle{a, b}]
+			if vector.Distance(a.Position, b.Position) < a.Radius+b.Radius && n == 0 {
+				t.Errorf("touching particles at %v and %v not paired", a.Position, b.Position)
+			}
+			if n > 1 {
+				t.Errorf("particles at %v and %v paired %d times", a.Position, b.Position, n)
+			}
+		}
+	}
+}
+
+func TestPairsOrderIsFixed(t *testing.T) {
+	particles := scatter(rand.New(rand.NewSource(2)), 200, 100)
+	h := NewSpatialHash(4)
+	var first [][2]*Particle
+	for run := 0; run < 5; run++ {
+		h.Clear()
+		for _, p := range particles {
+			h.Insert(p)
+		}
+		pairs := h.Pairs(nil)
+		if run == 0 {
+			first = pairs
+			continue
+		}
+		if len(pairs) != len(first) {
+			t.Fatalf("run %d: %d pairs, want %d", run, len(pairs), len(first))
+		}
+		for i := range pairs {
+			if pairs[i] != first[i] {
+				t.Fatalf("run %d: pair %d differs", run, i)
+			}
+		}
+	}
+	// The cells are visited row by row
+	for i := 1; i < len(first); i++ {
+		a, b := h.cellOf(first[i-1][0].Position), h.cellOf(first[i][0].Position)
+		if b.Y < a.Y || b.Y == a.Y && b.X < a.X {
+			t.Fatalf("pair %d in cell %v comes after cell %v", i, b, a)
+		}
+	}
+	// A fresh hash with the same particles in another order gives the same
+	// pairs of cells in the same order
+	fresh := NewSpatialHash(4)
+	for i := len(particles) - 1; i >= 0; i-- {
+		fresh.Insert(particles[i])
+	}
+	for i, pair := range fresh.Pairs(nil) {
+		if fresh.cellOf(pair[0].Position) != h.cellOf(first[i][0].Position) {
+			t.Fatalf("fresh hash: pair %d is in another cell", i)
+		}
+	}
+}
+
+func TestClearDropsEmptyCells(t *testing.T) {
+	p := NewParticle(vector.Vector{}, 1, 1)
+	h := NewSpatialHash(2)
+	for i := 0; i < 1000; i++ {
+		p.Position = vector.Vector{X: float64(i) * 3, Y: 0}
+		h.Clear()
+		h.Insert(p)
+	}
+	// The cell filled now and the one filled just before it are kept
+	if len(h.cells) > 2 {
+		t.Errorf("hash holds %d cells after the particle moved on, want at most 2", len(h.cells))
+	}
+}
@@ -0,0 +1,179 @@
+package verlet
+
+import (
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+// Particle is a point moved by Verlet integration. It has no velocity of its
+// own: how far it moved last step, from Previous to Position, is carried on
+// to the next. Moving Position alone moves the particle without giving it any
+// speed.
+type Particle struct {
+	Position     vector.Vector
+	Previous     vector.Vector // Position at the previous step
+	Acceleration vector.Vector // Acceleration for the next step on top of gravity, cleared after it
+	Radius       float64
+	Mass         float64 // 0 for a particle that never moves
+}
+
+// NewParticle creates a particle at rest.
+func NewParticle(position vector.Vector, radius, mass float64) *Particle {
+	return &Particle{Position: position, Previous: position, Radius: radius, Mass: mass}
+}
+
+// InverseMass returns 1/Mass, or 0 for a particle that never moves.
+func (p *Particle) InverseMass() float64 {
+	if p.Mass <= 0 {
+		return 0
+	}
+	return 1 / p.Mass
+}
+
+// Velocity returns how fast the particle moved over the last step of dt.
+func (p *Particle) Velocity(dt float64) vector.Vector {
+	return p.Position.Sub(p.Previous).Scale(1 / dt)
+}
+
+// SetVelocity makes the particle move at velocity over steps of dt.
+func (p *Particle) SetVelocity(velocity vector.Vector, dt float64) {
+	p.Previous = p.Position.Sub(velocity.Scale(dt))
+}
+
+// Accelerate adds an acceleration to the particle for the next step.
+func (p *Particle) Accelerate(acceleration vector.Vector) {
+	p.Acceleration = p.Acceleration.Add(acceleration)
+}
+
+// System is a set of Verlet particles with the constraints between them.
+// Every step moves the particles, then relaxes the constraints, collisions
+// and bounds a few times over. Verlet velocities are distances per step, so
+// keep dt fixed from step to step.
+type System struct {
+	Particles  []*Particle
+	Sticks     []*Stick
+	Pins       []*Pin
+	Bounds     []Bound       // Shapes that hold the particles in or out
+	Gravity    vector.Vector // Acceleration applied to every particle, such as {0, 98}
+	Damping    float64       // Share of velocity lost each step, from 0 to 1
+	Iterations int           // Relaxation passes per Step, 8 if 0
+	Collide    bool          // Keep the particles from overlapping each other
+
+	hash  *SpatialHash
+	pairs [][2]*Particle
+}
+
+// NewSystem creates a system with the given gravity and 8 relaxation passes
+// per step.
+func NewSystem(gravity vector.Vector) *System {
+	return &System{Gravity: gravity, Iterations: 8}
+}
+
+// Add adds particles to the system.
+func (s *System) Add(particles ...*Particle) {
+	s.Particles = append(s.Particles, particles...)
+}
+
+// AddStick joins two particles with a rigid stick at their current distance.
+func (s *System) AddStick(a, b *Particle) *Stick {
+	stick := NewStick(a, b)
+	s.Sticks = append(s.Sticks, stick)
+	return stick
+}
+
+// AddPin holds a particle where it is now.
+func (s *System) AddPin(p *Particle) *Pin {
+	pin := &Pin{Particle: p, Position: p.Position}
+	s.Pins = append(s.Pins, pin)
+	return pin
+}
+
+// AddBound adds a shape that holds the particles in or out.
+func (s *System) AddBound(bounds ...Bound) {
+	s.Bounds = append(s.Bounds, bounds...)
+}
+
+// Step advances the particles by dt.
+func (s *System) Step(dt float64) {
+	if dt <= 0 {
+		return
+	}
+	iterations := s.Iterations
+	if iterations <= 0 {
+		iterations = 8
+	}
+	for _, p := range s.Particles {
+		if p.InverseMass() == 0 {
+			p.Previous = p.Position
+			p.Acceleration = vector.Vector{}
+			continue
+		}
+		moved := p.Position.Sub(p.Previous).Scale(1 - s.Damping)
+		p.Previous = p.Position
+		p.Position = p.Position.Add(moved).Add(s.Gravity.Add(p.Acceleration).Scale(dt * dt))
+		p.Acceleration = vector.Vector{}
+	}
+	if s.Collide {
+		s.findPairs()
+	}
+	for i := 0; i < iterations; i++ {
+		for _, stick := range s.Sticks {
+			stick.Relax()
+		}
+		if s.Collide {
+			s.collide()
+		}
+		for _, p := range s.Particles {
+			for _, b := range s.Bounds {
+				b.Constrain(p)
+			}
+		}
+		// Pins last, so nothing pulls a pinned particle away
+		for _, pin := range s.Pins {
+			pin.Relax()
+		}
+	}
+}
+
+// findPairs lists the pairs of particles close enough to touch during this
+// step, using the spatial hash.
+func (s *System) findPairs() {
+	largest := 0.0
+	for _, p := range s.Particles {
+		largest = max(largest, p.Radius)
+	}
+	s.pairs = s.pairs[:0]
+	if largest == 0 {
+		return
+	}
+	if s.hash == nil {
+		s.hash = NewSpatialHash(2 * largest)
+	}
+	s.hash.CellSize = 2 * largest
+	s.hash.Clear()
+	for _, p := range s.Particles {
+		s.hash.Insert(p)
+	}
+	s.pairs = s.hash.Pairs(s.pairs)
+}
+
+// collide pushes every pair of overlapping particles apart, the lighter one
+// further.
+func (s *System) collide() {
+	for _, pair := range s.pairs {
+		a, b := pair[0], pair[1]
+		wa, wb := a.InverseMass(), b.InverseMass()
+		if wa+wb == 0 {
+			continue
+		}
+		d := b.Position.Sub(a.Position)
+		distance := d.Magnitude()
+		gap := a.Radius + b.Radius
+		if distance >= gap || distance == 0 {
+			continue
+		}
+		n := d.Scale(1 / distance)
+		push := (gap - distance) / (wa + wb)
+		a.Position = a.Position.Sub(n.Scale(push * wa))
+		b.Position = b.Position.Add(n.Scale(push * wb))
+	}
+}
@@ -0,0 +1,87 @@
+package verlet
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudransh61/Physix-go/pkg/vector"
+)
+
+func TestStickAndPinHoldAChain(t *testing.T) {
+	s := NewSystem(vector.Vector{X: 0, Y: 98})
+	var chain []*Particle
+	for i := 0; i < 10; i++ {
+		p := NewParticle(vector.Vector{X: float64(i) * 10, Y: 0}, 1, 1)
+		chain = append(chain, p)
+		s.Add(p)
+		if i > 0 {
+			s.AddStick(chain[i-1], p)
+		}
+	}
+	pin := s.AddPin(chain[0])
+	s.Iterations = 50
+	s.Damping = 0.02
+	for i := 0; i < 600; i++ {
+		s.Step(1.0 / 60)
+	}
+	if chain[0].Position != pin.Position {
+		t.Errorf("pinned particle at %v, want %v", chain[0].Position, pin.Position)
+	}
+	for i, stick := range s.Sticks {
+		if length := vector.Distance(stick.A.Position, stick.B.Position); math.Abs(length-stick.Length) > 0.01*stick.Length {
+			t.Errorf("stick %d is %v long, want %v", i, length, stick.Length)
+		}
+	}
+	// The chain hangs straight down from the pin
+	if end := chain[len(chain)-1].Position; math.Abs(end.X) > 1 || end.Y < 85 {
+		t.Errorf("end of the chain at %v, want it hanging below the pin", end)
+	}
+}
+
+func TestBoxHoldsParticlesIn(t *testing.T) {
+	s := NewSystem(vector.Vector{X: 0, Y: 98})
+	box := &Box{Min: vector.Vector{X: 0, Y: 0}, Max: vector.Vector{X: 100, Y: 100}}
+	s.AddBound(box)
+	p := NewParticle(vector.Vector{X: 50, Y: 50}, 5, 1)
+	p.SetVelocity(vector.Vector{X: 300, Y: 0}, 1.0/60)
+	s.Add(p)
+	for i := 0; i < 600; i++ {
+		s.Step(1.0 / 60)
+		if p.Position.X < 5-1e-9 || p.Position.X > 95+1e-9 || p.Position.Y > 95+1e-9 {
+			t.Fatalf("step %d: particle left the box at %v", i, p.Position)
+		}
+	}
+	if math.Abs(p.Position.Y-95) > 1e-9 {
+		t.Errorf("particle rests at %v, want on the floor at 95", p.Position.Y)
+	}
+}
+
+func TestSolidCircleHoldsParticlesOut(t *testing.T) {
+	s := NewSystem(vector.Vector{X: 0, Y: 98})
+	rock := &Circle{Center: vector.Vector{X: 0, Y: 100}, Radius: 20, Solid: true}
+	s.AddBound(rock)
+	p := NewParticle(vector.Vector{X: 0, Y: 0}, 5, 1)
+	s.Add(p)
+	for i := 0; i < 120; i++ {
+		s.Step(1.0 / 60)
+		if d := vector.Distance(p.Position, rock.Center); d < 25-1e-9 {
+			t.Fatalf("step %d: particle %v inside the rock", i, p.Position)
+		}
+	}
+}
+
+func TestCollideSeparatesParticles(t *testing.T) {
+	s := NewSystem(vector.Vector{})
+	s.Collide = true
+	light := NewParticle(vector.Vector{X: 0, Y: 0}, 2, 1)
+	heavy := NewParticle(vector.Vector{X: 1, Y: 0}, 2, 3)
+	s.Add(light, heavy)
+	s.Step(1.0 / 60)
+	if d := vector.Distance(light.Position, heavy.Position); d < 4-1e-9 {
+		t.Errorf("particles are %v apart, want at least 4", d)
+	}
+	// The lighter particle moves three times as far
+	if moved, other := -light.Position.X, heavy.Position.X-1; math.Abs(moved-3*other) > 1e-9 {
+		t.Errorf("light particle moved %v, heavy one %v", moved, other)
+	}
+}